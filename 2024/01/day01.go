@@ -1,48 +1,33 @@
-package main
+package day01
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"errors"
+	"io"
 	"sort"
-	"strconv"
-	"strings"
-)
-
-func parseInputFile(filename string) (leftList, rightList []int) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.Fields(scanner.Text())
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+	"github.com/thonda28/adventofcode/internal/validate"
+)
 
-		l, err := strconv.Atoi(line[0])
+func parseInputFile(r io.Reader) (leftList, rightList []int, err error) {
+	for line, err := range input.Scan(r) {
 		if err != nil {
-			log.Fatal(err)
+			return nil, nil, err
 		}
-		leftList = append(leftList, l)
-
-		r, err := strconv.Atoi(line[1])
+		pair, err := input.Pair(line.Text, "")
 		if err != nil {
-			log.Fatal(err)
+			return nil, nil, line.Wrap(err)
 		}
-		rightList = append(rightList, r)
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+		leftList = append(leftList, pair[0])
+		rightList = append(rightList, pair[1])
 	}
-	return leftList, rightList
+	return leftList, rightList, nil
 }
 
-func calcTotalDistance(leftList, rightList []int) (totalDistance int) {
+func calcTotalDistance(leftList, rightList []int) (totalDistance int, err error) {
 	if len(leftList) != len(rightList) {
-		log.Fatal("Length of left and right lists must be equal")
+		return 0, errors.New("length of left and right lists must be equal")
 	}
 
 	sortedLeftList := copyAndSort(leftList)
@@ -55,7 +40,7 @@ func calcTotalDistance(leftList, rightList []int) (totalDistance int) {
 		}
 		totalDistance += diff
 	}
-	return totalDistance
+	return totalDistance, nil
 }
 
 func copyAndSort(intList []int) []int {
@@ -77,17 +62,30 @@ func calcSimilarityScore(leftList, rightList []int) (similarityScore int) {
 	return similarityScore
 }
 
-func main() {
-	// const filename = "day01.example"
-	const filename = "day01.input"
+type Solver struct {
+	leftList, rightList []int
+}
 
-	leftList, rightList := parseInputFile(filename)
+func init() {
+	aoc.Register(2024, 1, func() aoc.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	var err error
+	s.leftList, s.rightList, err = parseInputFile(r)
+	return err
+}
 
-	// Part 1
-	totalDistance := calcTotalDistance(leftList, rightList)
-	fmt.Printf("totalDistance: %d\n", totalDistance)
+func (s *Solver) Invariants() []validate.Invariant {
+	return []validate.Invariant{
+		validate.FieldCount("two lists of equal length", "", 2),
+	}
+}
+
+func (s *Solver) Part1() (int, error) {
+	return calcTotalDistance(s.leftList, s.rightList)
+}
 
-	// Part 2
-	similarityScore := calcSimilarityScore(leftList, rightList)
-	fmt.Printf("similarityScore: %d\n", similarityScore)
+func (s *Solver) Part2() (int, error) {
+	return calcSimilarityScore(s.leftList, s.rightList), nil
 }
@@ -0,0 +1,35 @@
+package day01
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 1, generate)
+}
+
+// generate writes opts.Size lines of two 5-digit location IDs. About half of
+// the right list repeats IDs of the left list, so that part 2 has
+// similarities to count.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 1000})
+
+	leftList := make([]int, opts.Size)
+	for i := range leftList {
+		leftList[i] = 10000 + rng.IntN(90000)
+	}
+	for _, left := range leftList {
+		right := 10000 + rng.IntN(90000)
+		if rng.IntN(2) == 0 {
+			right = leftList[rng.IntN(len(leftList))]
+		}
+		if _, err := fmt.Fprintf(w, "%d   %d\n", left, right); err != nil {
+			return err
+		}
+	}
+	return nil
+}
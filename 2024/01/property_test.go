@@ -0,0 +1,48 @@
+package day01
+
+import (
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func split(pairs [][2]int16) (leftList, rightList []int) {
+	for _, pair := range pairs {
+		leftList = append(leftList, int(pair[0]))
+		rightList = append(rightList, int(pair[1]))
+	}
+	return leftList, rightList
+}
+
+func TestTotalDistanceSymmetric(t *testing.T) {
+	aoctest.Quick(t, func(pairs [][2]int16) bool {
+		leftList, rightList := split(pairs)
+		forward, err := calcTotalDistance(leftList, rightList)
+		if err != nil {
+			return false
+		}
+		backward, err := calcTotalDistance(rightList, leftList)
+		return err == nil && forward == backward
+	})
+}
+
+func TestSimilarityScoreCounts(t *testing.T) {
+	aoctest.Quick(t, func(pairs [][2]int16) bool {
+		leftList, rightList := split(pairs)
+		// few distinct IDs, so that they repeat
+		for i := range leftList {
+			leftList[i] %= 8
+			rightList[i] %= 8
+		}
+
+		want := 0
+		for _, left := range leftList {
+			for _, right := range rightList {
+				if left == right {
+					want += left
+				}
+			}
+		}
+		return calcSimilarityScore(leftList, rightList) == want
+	})
+}
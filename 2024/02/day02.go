@@ -1,39 +1,14 @@
-package main
+package day02
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"strings"
-)
-
-func parseInputFile(filename string) (reports [][]int) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
+	"io"
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := strings.Fields(scanner.Text())
-		var report []int
-		for _, data := range line {
-			num, err := strconv.Atoi(data)
-			if err != nil {
-				log.Fatal(err)
-			}
-			report = append(report, num)
-		}
-		reports = append(reports, report)
-	}
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+)
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-	return reports
+func parseInputFile(r io.Reader) (reports [][]int, err error) {
+	return input.Parse(r, input.Fields)
 }
 
 func isSafe(report []int) bool {
@@ -83,17 +58,24 @@ func countDampenedSafeReports(reports [][]int) (dampenedSafeReportCount int) {
 	return dampenedSafeReportCount
 }
 
-func main() {
-	// const filename = "day02.example"
-	const filename = "day02.input"
+type Solver struct {
+	reports [][]int
+}
+
+func init() {
+	aoc.Register(2024, 2, func() aoc.Solver { return &Solver{} })
+}
 
-	reports := parseInputFile(filename)
+func (s *Solver) Parse(r io.Reader) error {
+	var err error
+	s.reports, err = parseInputFile(r)
+	return err
+}
 
-	// Part 1
-	safeReportCount := countSafeReports(reports)
-	fmt.Printf("number of safe reports: %d\n", safeReportCount)
+func (s *Solver) Part1() (int, error) {
+	return countSafeReports(s.reports), nil
+}
 
-	// Part 2
-	safeReportWithDampenerCount := countDampenedSafeReports(reports)
-	fmt.Printf("number of safe reports using dampener: %d\n", safeReportWithDampenerCount)
+func (s *Solver) Part2() (int, error) {
+	return countDampenedSafeReports(s.reports), nil
 }
@@ -0,0 +1,50 @@
+package day02
+
+import (
+	"io"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 2, generate)
+}
+
+// generate writes opts.Size reports of 5 to opts.Length levels. Reports
+// start safe and some get one or two bad levels, so that every kind of
+// report shows up.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 1000, Length: 8})
+	minLength := min(5, opts.Length)
+
+	var buf []byte
+	for range opts.Size {
+		report := make([]int, minLength+rng.IntN(opts.Length-minLength+1))
+		order := 1
+		if rng.IntN(2) == 0 {
+			order = -1
+		}
+		report[0] = 3*len(report) + rng.IntN(60)
+		for i := 1; i < len(report); i++ {
+			report[i] = report[i-1] + order*(1+rng.IntN(3))
+		}
+		for range rng.IntN(3) {
+			report[rng.IntN(len(report))] = 1 + rng.IntN(99)
+		}
+
+		buf = buf[:0]
+		for i, level := range report {
+			if i > 0 {
+				buf = append(buf, ' ')
+			}
+			buf = strconv.AppendInt(buf, int64(level), 10)
+		}
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
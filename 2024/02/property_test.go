@@ -0,0 +1,19 @@
+package day02
+
+import (
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestDampenerOnlyAddsSafeReports(t *testing.T) {
+	aoctest.Quick(t, func(seed uint64) bool {
+		r := aoctest.Generated(t, generate, seed, aoc.GenOptions{Size: 20})
+		reports, err := parseInputFile(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return countDampenedSafeReports(reports) >= countSafeReports(reports)
+	})
+}
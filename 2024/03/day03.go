@@ -1,13 +1,12 @@
-package main
+package day03
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
 	"regexp"
 	"strconv"
-	"strings"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
 )
 
 type MulOperation struct {
@@ -19,106 +18,68 @@ func (mulop MulOperation) multiply() int {
 	return mulop.operand1 * mulop.operand2
 }
 
-func parseInputFile(filename string) (instruction string) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		instruction += scanner.Text()
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+// instructionPattern matches the instructions; the operands of mul are
+// submatches 1 and 2.
+var instructionPattern = regexp.MustCompile(`mul\((\d{1,3}),(\d{1,3})\)|do\(\)|don't\(\)`)
+
+// maxInstructionLen is the length of the longest instruction, mul(123,456).
+const maxInstructionLen = len("mul(123,456)")
+
+// parseInputFile streams the program and calls visit with each mul
+// operation and whether it is enabled by the do() and don't() before it.
+// The line breaks are not part of the program, so the end of each line
+// that could still start an instruction is carried over to the next.
+func parseInputFile(r io.Reader, visit func(mulOperation MulOperation, enabled bool)) error {
+	enabled := true
+	var tail string
+	for line, err := range input.Scan(r) {
+		if err != nil {
+			return err
+		}
+		chunk := tail + line.Text
+
+		end := 0
+		for _, match := range instructionPattern.FindAllStringSubmatchIndex(chunk, -1) {
+			switch chunk[match[0]:match[1]] {
+			case "do()":
+				enabled = true
+			case "don't()":
+				enabled = false
+			default:
+				mulOperand1, _ := strconv.Atoi(chunk[match[2]:match[3]])
+				mulOperand2, _ := strconv.Atoi(chunk[match[4]:match[5]])
+				visit(MulOperation{mulOperand1, mulOperand2}, enabled)
+			}
+			end = match[1]
+		}
+		tail = chunk[max(end, len(chunk)-(maxInstructionLen-1)):]
 	}
-	return instruction
+	return nil
 }
 
-func extractMulOperations(instruction string) (mulOperations []MulOperation) {
-	mul_pattern := `mul\((\d{1,3}),(\d{1,3})\)`
-	re, err := regexp.Compile(mul_pattern)
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	// https://pkg.go.dev/github.com/shogo82148/std/regexp#Regexp.FindAllStringSubmatch
-	matches := re.FindAllStringSubmatch(instruction, -1)
-
-	// exmaple of matches:
-	// matches = [][]string{
-	// 	{ "mul(2,4)",  "2", "4"},
-	// 	{ "mul(5,5)",  "5", "5"},
-	// 	{"mul(11,8)", "11", "8"},
-	// 	{ "mul(8,5)",  "8", "5"},
-	// }
-
-	for _, match := range matches {
-		mulOperand1, _ := strconv.Atoi(match[1])
-		mulOperand2, _ := strconv.Atoi(match[2])
-		mulOperation := MulOperation{mulOperand1, mulOperand2}
-		mulOperations = append(mulOperations, mulOperation)
-	}
-	return mulOperations
+type Solver struct {
+	result        int
+	enabledResult int
 }
 
-func findSearchRanges(instruction string) (searchRanges [][2]int) {
-	active := true
-	start := 0
+func init() {
+	aoc.Register(2024, 3, func() aoc.Solver { return &Solver{} })
+}
 
-	position := 0
-	for {
-		if active {
-			index := strings.Index(instruction[position:], "don't()")
-			if index == -1 {
-				searchRanges = append(searchRanges, [2]int{start, len(instruction)})
-				break
-			}
-			end := position + index + len("don't()")
-			searchRanges = append(searchRanges, [2]int{start, end})
-			active = false
-			position = end
-		} else {
-			index := strings.Index(instruction[position:], "do()")
-			if index == -1 {
-				break
-			}
-			start = position + index + len("do()")
-			active = true
-			position = start
+// Parse keeps only the sums of the products, not the program itself.
+func (s *Solver) Parse(r io.Reader) error {
+	return parseInputFile(r, func(mulOperation MulOperation, enabled bool) {
+		s.result += mulOperation.multiply()
+		if enabled {
+			s.enabledResult += mulOperation.multiply()
 		}
-	}
-	return searchRanges
+	})
 }
 
-func main() {
-	// const filename = "day03.example"
-	const filename = "day03.input"
-
-	instruction := parseInputFile(filename)
-
-	// Part 1
-	mulOperations := extractMulOperations(instruction)
-	result := 0
-	for _, mulOperation := range mulOperations {
-		result += mulOperation.multiply()
-	}
-	fmt.Printf("sum of the results of all multiplications: %d\n", result)
-
-	// Part 2
-	var enabledMulOperations []MulOperation
-	searchRanges := findSearchRanges(instruction)
-	for _, searchRange := range searchRanges {
-		start, end := searchRange[0], searchRange[1]
-		mulOperations := extractMulOperations(instruction[start:end])
-		enabledMulOperations = append(enabledMulOperations, mulOperations...)
-	}
+func (s *Solver) Part1() (int, error) {
+	return s.result, nil
+}
 
-	enabledResult := 0
-	for _, enabledMulOperation := range enabledMulOperations {
-		enabledResult += enabledMulOperation.multiply()
-	}
-	fmt.Printf("sum of the results of all enabled multiplications: %d\n", enabledResult)
+func (s *Solver) Part2() (int, error) {
+	return s.enabledResult, nil
 }
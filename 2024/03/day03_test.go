@@ -0,0 +1,33 @@
+package day03
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2024, 3)
+}
+
+func TestInstructionsAcrossLines(t *testing.T) {
+	program := "xmul(2,4)&mul[3,7]!^don't()_mul(5,5)+mul(32,64](mul(11,8)undo()?mul(8,5))"
+	for i := range len(program) + 1 {
+		s := &Solver{}
+		if err := s.Parse(strings.NewReader(program[:i] + "\n" + program[i:])); err != nil {
+			t.Fatal(err)
+		}
+		if s.result != 161 || s.enabledResult != 48 {
+			t.Errorf("line break at %d: %d and %d, want 161 and 48", i, s.result, s.enabledResult)
+		}
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Benchmark(b, 2024, 3)
+}
+
+func FuzzParse(f *testing.F) {
+	aoctest.FuzzParse(f, 2024, 3)
+}
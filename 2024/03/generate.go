@@ -0,0 +1,56 @@
+package day03
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 3, generate)
+}
+
+// corruptions look like instructions but must not be executed.
+var corruptions = []string{
+	"mul(4*", "mul(6,9!", "?(12,34)", "mul ( 2 , 4 )", "mul[3,7]", "mul(1234,5)",
+	"do_not_mul(5,5)", "don't", "do(", "mul(32,64]", "from()", "select()", "why()",
+}
+
+const noise = "!@#$%^&*()[]{}<>,'+-_? :;/~mulwhohowdon"
+
+// generate writes opts.Size instructions, mul, do or don't, separated by
+// corrupted memory and split into lines of opts.Length instructions.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 3000, Length: 500})
+
+	var buf []byte
+	for i := range opts.Size {
+		switch n := rng.IntN(20); {
+		case n == 0:
+			buf = append(buf, "do()"...)
+		case n == 1:
+			buf = append(buf, "don't()"...)
+		default:
+			buf = fmt.Appendf(buf, "mul(%d,%d)", 1+rng.IntN(999), 1+rng.IntN(999))
+		}
+
+		for range rng.IntN(4) {
+			if rng.IntN(3) == 0 {
+				buf = append(buf, corruptions[rng.IntN(len(corruptions))]...)
+			} else {
+				buf = append(buf, noise[rng.IntN(len(noise))])
+			}
+		}
+
+		if (i+1)%opts.Length == 0 || i == opts.Size-1 {
+			buf = append(buf, '\n')
+			if _, err := w.Write(buf); err != nil {
+				return err
+			}
+			buf = buf[:0]
+		}
+	}
+	return nil
+}
@@ -1,135 +1,100 @@
-package main
+package day04
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
-)
-
-func parseInputFile(filename string) (texts []string) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
+	"io"
 
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		line := scanner.Text()
-		texts = append(texts, line)
-	}
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/geom"
+	"github.com/thonda28/adventofcode/internal/grid"
+	"github.com/thonda28/adventofcode/internal/input"
+	"github.com/thonda28/adventofcode/internal/validate"
+)
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-	return texts
+func parseInputFile(r io.Reader) (texts *grid.Grid[byte], err error) {
+	return input.Grid(r)
 }
 
 func isFoundInDirection(
-	texts []string,
+	texts *grid.Grid[byte],
 	word string,
-	startRow,
-	startCol int,
-	direction [2]int,
+	start geom.Point,
+	direction geom.Direction,
 ) bool {
-	numRows := len(texts)
-	numCols := len(texts[0])
-
-	row := startRow
-	col := startCol
 	wordIndex := 0
-	for {
-		isInside := 0 <= row && row < numRows && 0 <= col && col < numCols
-		if !isInside {
-			return false
-		}
-		if texts[row][col] != word[wordIndex] {
+	for _, b := range texts.Line(start, direction.Delta()) {
+		if b != word[wordIndex] {
 			return false
 		}
 		if wordIndex == len(word)-1 {
 			return true
 		}
-
-		row += direction[0]
-		col += direction[1]
 		wordIndex += 1
 	}
+	return false
 }
 
-func countWord(texts []string, word string) (wordCount int) {
-	numRows := len(texts)
-	numCols := len(texts[0])
-
-	directions := [][2]int{
-		{1, 0},
-		{1, 1},
-		{0, 1},
-		{-1, 1},
-		{-1, 0},
-		{-1, -1},
-		{0, -1},
-		{1, -1},
-	}
-	for row := 0; row < numRows; row++ {
-		for col := 0; col < numCols; col++ {
-			for _, direction := range directions {
-				if isFoundInDirection(texts, word, row, col, direction) {
-					wordCount++
-				}
+func countWord(texts *grid.Grid[byte], word string) (wordCount int) {
+	for position := range texts.All() {
+		for _, direction := range geom.Directions8 {
+			if isFoundInDirection(texts, word, position, direction) {
+				wordCount++
 			}
 		}
 	}
 	return wordCount
 }
 
-func isFoundCrossShapeMas(texts []string, row, col int) bool {
-	numRows := len(texts)
-	numCols := len(texts[0])
-	if !(1 <= row && row < numRows-1 && 1 <= col && col < numCols-1) {
+func isFoundCrossShapeMas(texts *grid.Grid[byte], position geom.Point) bool {
+	row, col := position.Row, position.Col
+	if !(1 <= row && row < texts.NumRows()-1 && 1 <= col && col < texts.NumCols()-1) {
 		return false
 	}
 
-	if texts[row][col] != 'A' {
+	if texts.Get(position) != 'A' {
 		return false
 	}
 
 	isMasPair := func(x, y byte) bool {
 		return (x == 'M' && y == 'S') || (x == 'S' && y == 'M')
 	}
-	topLeft := texts[row-1][col-1]
-	bottomRight := texts[row+1][col+1]
-	bottomLeft := texts[row+1][col-1]
-	topRight := texts[row-1][col+1]
+	topLeft := texts.Get(position.Move(geom.UpLeft))
+	bottomRight := texts.Get(position.Move(geom.DownRight))
+	bottomLeft := texts.Get(position.Move(geom.DownLeft))
+	topRight := texts.Get(position.Move(geom.UpRight))
 	return isMasPair(topLeft, bottomRight) && isMasPair(bottomLeft, topRight)
 }
 
-func countCrossShapeMas(texts []string) (crossShapeMasCount int) {
-	numRows := len(texts)
-	numCols := len(texts[0])
-
-	for row := 0; row < numRows; row++ {
-		for col := 0; col < numCols; col++ {
-			if isFoundCrossShapeMas(texts, row, col) {
-				crossShapeMasCount++
-			}
+func countCrossShapeMas(texts *grid.Grid[byte]) (crossShapeMasCount int) {
+	for position := range texts.All() {
+		if isFoundCrossShapeMas(texts, position) {
+			crossShapeMasCount++
 		}
 	}
 	return crossShapeMasCount
 }
 
-func main() {
-	// const filename = "day04.example" // size: 10x10
-	const filename = "day04.input" // size: 140x140
+type Solver struct {
+	texts *grid.Grid[byte]
+}
+
+func init() {
+	aoc.Register(2024, 4, func() aoc.Solver { return &Solver{} })
+}
 
-	texts := parseInputFile(filename)
+func (s *Solver) Parse(r io.Reader) error {
+	var err error
+	s.texts, err = parseInputFile(r)
+	return err
+}
 
-	// Part 1
-	word := "XMAS"
-	wordCount := countWord(texts, word)
-	fmt.Printf("number of \"%s\": %d\n", word, wordCount)
+func (s *Solver) Invariants() []validate.Invariant {
+	return []validate.Invariant{validate.Rectangular()}
+}
+
+func (s *Solver) Part1() (int, error) {
+	return countWord(s.texts, "XMAS"), nil
+}
 
-	// Part 2
-	crossShapeMasCount := countCrossShapeMas(texts)
-	fmt.Printf("number of \"X-MAS\": %d\n", crossShapeMasCount)
+func (s *Solver) Part2() (int, error) {
+	return countCrossShapeMas(s.texts), nil
 }
@@ -0,0 +1,19 @@
+package day04
+
+import (
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2024, 4)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Benchmark(b, 2024, 4)
+}
+
+func FuzzParse(f *testing.F) {
+	aoctest.FuzzParse(f, 2024, 4)
+}
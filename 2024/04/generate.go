@@ -0,0 +1,30 @@
+package day04
+
+import (
+	"io"
+	"math/rand/v2"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 4, generate)
+}
+
+// generate writes an opts.Size x opts.Size grid of random X, M, A and S.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 140})
+
+	const letters = "XMAS"
+	row := make([]byte, opts.Size+1)
+	row[opts.Size] = '\n'
+	for range opts.Size {
+		for col := range opts.Size {
+			row[col] = letters[rng.IntN(len(letters))]
+		}
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,13 +1,13 @@
-package main
+package day05
 
 import (
-	"bufio"
 	"fmt"
-	"log"
-	"os"
+	"io"
 	"reflect"
-	"strconv"
-	"strings"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+	"github.com/thonda28/adventofcode/internal/validate"
 )
 
 type Stack[T any] struct {
@@ -28,66 +28,37 @@ func (s *Stack[T]) Pop() (T, bool) {
 	return top, true
 }
 
-func parseInputFile(filename string) (rules [][2]int, orders [][]int) {
-	file, err := os.Open(filename)
+func parseInputFile(r io.Reader) (rules [][2]int, orders [][]int, err error) {
+	sections, err := input.Sections(r)
 	if err != nil {
-		log.Fatal(err)
+		return nil, nil, err
 	}
-	defer file.Close()
-
-	var data strings.Builder
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		data.WriteString(scanner.Text() + "\n")
+	if len(sections) != 2 {
+		return nil, nil, input.FileError(input.Name(r), fmt.Errorf("input data must be two sections, got %d", len(sections)))
 	}
 
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	rules, err = parseRules(sections[0])
+	if err != nil {
+		return nil, nil, err
 	}
-
-	sections := strings.Split(data.String(), "\n\n")
-	if len(sections) != 2 {
-		log.Fatal("Input data must be two sections.")
+	orders, err = parseOrders(sections[1])
+	if err != nil {
+		return nil, nil, err
 	}
 
-	rules = parseRules(strings.Fields(sections[0]))
-	orders = parseOrders(strings.Fields(sections[1]))
-
-	return rules, orders
+	return rules, orders, nil
 }
 
-func parseRules(lines []string) (rules [][2]int) {
-	for _, line := range lines {
-		parts := strings.Split(line, "|")
-		if len(parts) != 2 {
-			log.Fatal("Input data must be two parts.")
-		}
-		before, err := strconv.Atoi(strings.TrimSpace(parts[0]))
-		if err != nil {
-			log.Fatal(err)
-		}
-		after, err := strconv.Atoi(strings.TrimSpace(parts[1]))
-		if err != nil {
-			log.Fatal(err)
-		}
-		rules = append(rules, [2]int{before, after})
-	}
-	return rules
+func parseRules(lines []input.Line) (rules [][2]int, err error) {
+	return input.ParseLines(lines, func(text string) ([2]int, error) {
+		return input.Pair(text, "|")
+	})
 }
 
-func parseOrders(lines []string) (orders [][]int) {
-	for _, line := range lines {
-		var order []int
-		for _, page := range strings.Split(line, ",") {
-			intPage, err := strconv.Atoi(page)
-			if err != nil {
-				log.Fatal(err)
-			}
-			order = append(order, intPage)
-		}
-		orders = append(orders, order)
-	}
-	return orders
+func parseOrders(lines []input.Line) (orders [][]int, err error) {
+	return input.ParseLines(lines, func(text string) ([]int, error) {
+		return input.SplitInts(text, ",")
+	})
 }
 
 func isCorrectlyOrdered(rules [][2]int, order []int) bool {
@@ -155,35 +126,85 @@ func sortByRules(rules [][2]int, order []int) (sortedOrder []int) {
 	return sortedOrder
 }
 
-// Constraints:
+// Constraints (1-1, 2 and 3 are checked by Invariants):
 // 1. The result of the Topological Sort is uniquely determined
 //    1-1. The graph is a Directed Acyclic Graph (DAG)
 //    1-2. At each step, there is only one vertex with an in-degree of 0
 // 2. The length of the order is always odd
 // 3. All elements in the order are unique
 
-func main() {
-	// const filename = "day05.example"
-	const filename = "day05.input"
+type Solver struct {
+	rules  [][2]int
+	orders [][]int
+}
 
-	rules, orders := parseInputFile(filename)
+func init() {
+	aoc.Register(2024, 5, func() aoc.Solver { return &Solver{} })
+}
 
-	// Part 1
+func (s *Solver) Parse(r io.Reader) error {
+	var err error
+	s.rules, s.orders, err = parseInputFile(r)
+	return err
+}
+
+func (s *Solver) Invariants() []validate.Invariant {
+	return []validate.Invariant{
+		{Name: "acyclic rules", Check: checkAcyclic},
+		validate.InSection(1, validate.OddLength(",")),
+		validate.InSection(1, validate.Unique(",")),
+	}
+}
+
+// checkAcyclic reports the orders whose pages are ordered by cyclic rules,
+// which sortByRules cannot sort. Malformed lines are left to Parse.
+func checkAcyclic(lines []input.Line) (errs []error) {
+	sections := input.SplitSections(lines)
+	if len(sections) != 2 {
+		return nil
+	}
+	rules, err := parseRules(sections[0])
+	if err != nil {
+		return nil
+	}
+
+	for _, line := range sections[1] {
+		order, err := input.SplitInts(line.Text, ",")
+		if err != nil {
+			continue
+		}
+		pages := make(map[int]struct{})
+		for _, page := range order {
+			pages[page] = struct{}{}
+		}
+		if sortedOrder := sortByRules(rules, order); len(sortedOrder) < len(pages) {
+			errs = append(errs, line.Errorf("rules between the pages form a cycle"))
+		}
+	}
+	return errs
+}
+
+func (s *Solver) Part1() (int, error) {
 	sumOfCorrectOrders := 0
-	for _, order := range orders {
-		if isCorrectlyOrdered(rules, order) {
+	for _, order := range s.orders {
+		if isCorrectlyOrdered(s.rules, order) {
 			sumOfCorrectOrders += order[len(order)/2]
 		}
 	}
-	fmt.Printf("sum of the middle page number from correctly-ordered updates: %d\n", sumOfCorrectOrders)
+	return sumOfCorrectOrders, nil
+}
 
-	// Part 2
+func (s *Solver) Part2() (int, error) {
 	sumOfIncorrectOrders := 0
-	for _, order := range orders {
-		sortedOrder := sortByRules(rules, order)
+	for _, order := range s.orders {
+		sortedOrder := sortByRules(s.rules, order)
+		if len(sortedOrder) < len(order) {
+			// only possible when the invariants were skipped
+			return 0, fmt.Errorf("rules between the pages of %v form a cycle", order)
+		}
 		if !reflect.DeepEqual(order, sortedOrder) {
 			sumOfIncorrectOrders += sortedOrder[len(sortedOrder)/2]
 		}
 	}
-	fmt.Printf("sum of the middle page number from incorrectly-ordered updates: %d\n", sumOfIncorrectOrders)
+	return sumOfIncorrectOrders, nil
 }
@@ -0,0 +1,30 @@
+package day05
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2024, 5)
+}
+
+func TestCyclicRulesWithoutValidation(t *testing.T) {
+	solver := &Solver{}
+	if err := solver.Parse(strings.NewReader("1|2\n2|1\n\n1,2,3\n")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := solver.Part2(); err == nil {
+		t.Error("Part2 succeeded with cyclic rules")
+	}
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Benchmark(b, 2024, 5)
+}
+
+func FuzzParse(f *testing.F) {
+	aoctest.FuzzParse(f, 2024, 5)
+}
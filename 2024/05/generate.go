@@ -0,0 +1,64 @@
+package day05
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 5, generate)
+}
+
+// generate writes rules ordering 49 pages and opts.Size updates of up to
+// opts.Length pages. The rules follow one random total order of the pages,
+// so every update has acyclic rules and a unique correct order. About half
+// of the updates are already correctly ordered.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 200, Length: 23})
+
+	pages := rng.Perm(90)[:49]
+	for i := range pages {
+		pages[i] += 10
+	}
+	for _, i := range rng.Perm(len(pages) * len(pages)) {
+		before, after := i/len(pages), i%len(pages)
+		if before >= after {
+			continue
+		}
+		if _, err := fmt.Fprintf(w, "%d|%d\n", pages[before], pages[after]); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+
+	// the length of an update is odd
+	maxLength := max(1, min(opts.Length, len(pages)))
+	var buf []byte
+	for range opts.Size {
+		length := 1 + 2*rng.IntN((maxLength+1)/2)
+		indices := rng.Perm(len(pages))[:length]
+		if rng.IntN(2) == 0 {
+			slices.Sort(indices)
+		}
+
+		buf = buf[:0]
+		for i, index := range indices {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = strconv.AppendInt(buf, int64(pages[index]), 10)
+		}
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
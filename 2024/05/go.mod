@@ -1,3 +0,0 @@
-module github.com/thonda28/adventofcode/2024/05
-
-go 1.23.2
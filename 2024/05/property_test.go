@@ -0,0 +1,31 @@
+package day05
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestSortedByRulesIsCorrectlyOrdered(t *testing.T) {
+	aoctest.Quick(t, func(seed uint64) bool {
+		r := aoctest.Generated(t, generate, seed, aoc.GenOptions{Size: 10})
+		rules, orders, err := parseInputFile(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, order := range orders {
+			sortedOrder := sortByRules(rules, order)
+			if !isCorrectlyOrdered(rules, sortedOrder) {
+				return false
+			}
+			// the same pages, only reordered
+			if !slices.Equal(slices.Sorted(slices.Values(order)), slices.Sorted(slices.Values(sortedOrder))) {
+				return false
+			}
+		}
+		return true
+	})
+}
@@ -1,179 +1,138 @@
-package main
+package day06
 
 import (
-	"bufio"
+	"context"
+	"errors"
 	"fmt"
-	"log"
-	"os"
+	"io"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/geom"
+	"github.com/thonda28/adventofcode/internal/grid"
+	"github.com/thonda28/adventofcode/internal/input"
+	"github.com/thonda28/adventofcode/internal/validate"
 )
 
 const (
-	StartMarker   = "^"
-	Obstruction   = "#"
-	NoObstruction = "."
+	StartMarker   = '^'
+	Obstruction   = '#'
+	NoObstruction = '.'
 )
 
-type Position struct {
-	Row, Col int
-}
-
-type Direction int
-
 type PositionDirection struct {
-	pos Position
-	dir Direction
+	pos geom.Point
+	dir geom.Direction
 }
 
-const (
-	Up Direction = iota
-	Right
-	Down
-	Left
-)
-
-var moves = map[Direction][2]int{
-	Up:    {-1, 0},
-	Right: {0, 1},
-	Down:  {1, 0},
-	Left:  {0, -1},
+func parseInputFile(r io.Reader) (field *grid.Grid[byte], err error) {
+	return input.Grid(r)
 }
 
-func parseInputFile(filename string) (field []string) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		field = append(field, scanner.Text())
+func findStart(filename string, field *grid.Grid[byte]) (start Guard, err error) {
+	guards := FindGuards(field)
+	if len(guards) == 0 {
+		return start, input.FileError(filename, errors.New("start position does not exist"))
 	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
+	if len(guards) > 1 {
+		second := guards[1].Position
+		return start, &input.ParseError{
+			File:   filename,
+			Line:   second.Row + 1,
+			Column: second.Col + 1,
+			Text:   string(field.Get(second)),
+			Err:    fmt.Errorf("start position must be a single location, found %d", len(guards)),
+		}
 	}
-	return field
+	return guards[0], nil
 }
 
-func findStart(field []string) (startPosition Position) {
-	numRows := len(field)
-	numCols := len(field[0])
+type Solver struct {
+	lab   *Lab
+	start Guard
 
-	found := false
-	for row := 0; row < numRows; row++ {
-		for col := 0; col < numCols; col++ {
-			if string(field[row][col]) != StartMarker {
-				continue
-			}
-			if found {
-				log.Fatal("Start position must be a single location.")
-			}
-			startPosition = Position{row, col}
-			found = true
-		}
-	}
+	// Workers is the number of goroutines searching obstructions in part 2,
+	// GOMAXPROCS if not positive.
+	Workers int
 
-	if !found {
-		log.Fatal("Start position does not exist.")
-	}
-	return startPosition
+	// the details of the latest answers, for Report
+	patrolReport      *PatrolReport
+	obstructionReport *ObstructionReport
 }
 
-func patrol(
-	field []string,
-	startPosition Position,
-) (visited map[Position]struct{}, canExit bool) {
-	numRows := len(field)
-	numCols := len(field[0])
+func init() {
+	aoc.Register(2024, 6, func() aoc.Solver { return &Solver{} })
+}
 
-	isInside := func(p Position) bool {
-		return (0 <= p.Row && p.Row < numRows) && (0 <= p.Col && p.Col < numCols)
+func (s *Solver) Parse(r io.Reader) error {
+	field, err := parseInputFile(r)
+	if err != nil {
+		return err
 	}
-
-	directions := []Direction{Up, Right, Down, Left}
-	directionIndex := 0
-
-	position := startPosition
-	visited = make(map[Position]struct{})
-	positionDirectionHistory := make(map[PositionDirection]struct{})
-	for {
-		direction := directions[directionIndex]
-		if isInfinitePatrol(position, direction, positionDirectionHistory) {
-			return nil, false
-		}
-		visited[position] = struct{}{}
-		positionDirectionHistory[PositionDirection{position, direction}] = struct{}{}
-
-		move := moves[direction]
-		nextPosition := Position{position.Row + move[0], position.Col + move[1]}
-		if !isInside(nextPosition) {
-			break
-		}
-		if string(field[nextPosition.Row][nextPosition.Col]) == Obstruction {
-			directionIndex = (directionIndex + 1) % len(directions)
-			continue
-		}
-		position = nextPosition
+	s.start, err = findStart(input.Name(r), field)
+	if err != nil {
+		return err
 	}
-
-	return visited, true
+	s.lab = NewLab(field)
+	return nil
 }
 
-func isInfinitePatrol(
-	currentPosition Position,
-	currentDirection Direction,
-	positionDirectionHistory map[PositionDirection]struct{},
-) bool {
-	posDir := PositionDirection{currentPosition, currentDirection}
-	// same position and direction encountered again
-	_, ok := positionDirectionHistory[posDir]
-	return ok
+func (s *Solver) Invariants() []validate.Invariant {
+	return []validate.Invariant{
+		validate.Rectangular(),
+		validate.SingleMarker(StartMarkers),
+	}
 }
 
-func countStuckableObstruction(field []string, startPosition Position) (stuckableObstructionCount int) {
-	numRows := len(field)
-	numCols := len(field[0])
-
-	for row := 0; row < numRows; row++ {
-		for col := 0; col < numCols; col++ {
-			if row == startPosition.Row && col == startPosition.Col {
-				continue
-			}
-			if string(field[row][col]) == Obstruction {
-				continue
-			}
-
-			// place an obstruction temporarily
-			field[row] = field[row][:col] + Obstruction + field[row][col+1:]
-
-			if _, canExit := patrol(field, startPosition); !canExit {
-				stuckableObstructionCount++
-			}
-
-			// remove the temporarily placed obstruction
-			field[row] = field[row][:col] + NoObstruction + field[row][col+1:]
-		}
+func (s *Solver) Part1() (int, error) {
+	visitedCount, loop := s.lab.patrol(s.start, nil)
+	s.patrolReport = &PatrolReport{Loop: loop}
+	if loop != nil {
+		return 0, fmt.Errorf("cannot exit this field: the guard loops every %d steps from %v facing %v",
+			loop.Period, loop.Entry.Position, loop.Entry.Direction)
 	}
+	s.patrolReport.Visited = visitedCount
+	return visitedCount, nil
+}
 
-	return stuckableObstructionCount
+func (s *Solver) Part2() (int, error) {
+	positions, err := s.lab.LoopingObstructions(context.Background(), s.start, s.Workers)
+	if err != nil {
+		return 0, err
+	}
+	s.obstructionReport = &ObstructionReport{positions}
+	return len(positions), nil
 }
 
-func main() {
-	// const filename = "day06.example"
-	const filename = "day06.input"
+// PatrolReport details part 1. Loop is set instead of Visited if the guard
+// never leaves the lab.
+type PatrolReport struct {
+	Visited int       `json:"visited,omitempty"`
+	Loop    *LoopInfo `json:"loop,omitempty"`
+}
 
-	field := parseInputFile(filename)
+// ObstructionReport details part 2.
+type ObstructionReport struct {
+	Obstructions []geom.Point `json:"obstructions"`
+}
 
-	// Part 1
-	startPosition := findStart(field)
-	visitedPositions, canExit := patrol(field, startPosition)
-	if !canExit {
-		log.Fatal("Cannot exit this field.")
+// Report details the latest answer of the part, solving it first if it
+// has not been solved yet.
+func (s *Solver) Report(part int) (any, error) {
+	switch part {
+	case 1:
+		if s.patrolReport == nil {
+			// a loop fails the part but is what the report is about
+			_, _ = s.Part1()
+		}
+		return *s.patrolReport, nil
+	case 2:
+		if s.obstructionReport == nil {
+			if _, err := s.Part2(); err != nil {
+				return nil, err
+			}
+		}
+		return *s.obstructionReport, nil
+	default:
+		return nil, fmt.Errorf("invalid part %d", part)
 	}
-	fmt.Printf("number of patrolled positions: %d\n", len(visitedPositions))
-
-	// Part 2
-	stuckableObstructionCount := countStuckableObstruction(field, startPosition)
-	fmt.Printf("number of positions where placing an obstruction can make the guard stuck: %d\n", stuckableObstructionCount)
 }
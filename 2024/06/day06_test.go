@@ -0,0 +1,40 @@
+package day06
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestExamples(t *testing.T) {
+	aoctest.Examples(t, 2024, 6)
+}
+
+func BenchmarkSolver(b *testing.B) {
+	aoctest.Benchmark(b, 2024, 6)
+}
+
+// BenchmarkLargeMap solves a generated 1000x1000 map, about 60 times the
+// real input, where the guard visits about a quarter of the cells.
+func BenchmarkLargeMap(b *testing.B) {
+	solver := &Solver{}
+	if err := solver.Parse(aoctest.Generated(b, generate, 1, aoc.GenOptions{Size: 1000})); err != nil {
+		b.Fatal(err)
+	}
+	for part := 1; part <= 2; part++ {
+		b.Run(fmt.Sprintf("Part%d", part), func(b *testing.B) {
+			b.ReportAllocs()
+			for range b.N {
+				if _, err := aoc.SolvePart(solver, part); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func FuzzParse(f *testing.F) {
+	aoctest.FuzzParse(f, 2024, 6)
+}
@@ -0,0 +1,101 @@
+package day06
+
+import (
+	"io"
+	"math/rand/v2"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/geom"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 6, generate)
+}
+
+// onPath marks the cells of the guard's path while generating.
+const onPath = 'o'
+
+// generate writes an opts.Size x opts.Size map where the guard, facing up
+// near the center, walks an outward spiral until it leaves the map, so that
+// like in a real input it visits a good part of the map, whatever its size.
+// The spiral turns 2 to 4 cells outside the part walked so far. The other
+// cells get about 5% obstructions, like a real input, which the guard only
+// runs into once an obstruction is added on its path.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 130})
+	size := opts.Size
+
+	rows := make([][]byte, size)
+	for row := range rows {
+		rows[row] = make([]byte, size+1)
+		for col := range size {
+			rows[row][col] = NoObstruction
+		}
+		rows[row][size] = '\n'
+	}
+	inBounds := func(p geom.Point) bool {
+		return 0 <= p.Row && p.Row < size && 0 <= p.Col && p.Col < size
+	}
+
+	jitter := max(1, size/10)
+	start := geom.Point{
+		Row: min(size-1, size/2+rng.IntN(jitter)-jitter/2),
+		Col: min(size-1, size/2+rng.IntN(jitter)-jitter/2),
+	}
+	rows[start.Row][start.Col] = onPath
+
+	// the walked part is the rectangle from low to high
+	position, direction := start, geom.Up
+	low, high := start, start
+	for {
+		gap := 2 + rng.IntN(3)
+		var length int
+		switch direction {
+		case geom.Up:
+			length = position.Row - (low.Row - gap)
+		case geom.Right:
+			length = high.Col + gap - position.Col
+		case geom.Down:
+			length = high.Row + gap - position.Row
+		case geom.Left:
+			length = position.Col - (low.Col - gap)
+		}
+
+		for range length {
+			next := position.Move(direction)
+			if !inBounds(next) {
+				break
+			}
+			position = next
+			rows[position.Row][position.Col] = onPath
+		}
+		turn := position.Move(direction)
+		if !inBounds(turn) {
+			break
+		}
+		rows[turn.Row][turn.Col] = Obstruction
+
+		low = geom.Point{Row: min(low.Row, position.Row), Col: min(low.Col, position.Col)}
+		high = geom.Point{Row: max(high.Row, position.Row), Col: max(high.Col, position.Col)}
+		direction = direction.TurnRight()
+	}
+
+	for _, row := range rows {
+		for col := range size {
+			switch {
+			case row[col] == onPath:
+				row[col] = NoObstruction
+			case rng.IntN(20) == 0:
+				row[col] = Obstruction
+			}
+		}
+	}
+	rows[start.Row][start.Col] = StartMarker
+
+	for _, row := range rows {
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
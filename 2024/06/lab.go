@@ -0,0 +1,318 @@
+package day06
+
+import (
+	"context"
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/thonda28/adventofcode/internal/geom"
+	"github.com/thonda28/adventofcode/internal/grid"
+)
+
+// Lab is the map of the lab prepared for fast patrols. Besides a bitset of
+// the obstructions it keeps, per row and per column, the sorted indices of
+// the obstructions, so that a guard can jump from one turn to the next
+// instead of walking cell by cell.
+type Lab struct {
+	numRows, numCols int
+	obstructed       bitset
+	rowObstructions  [][]int // columns of the obstructions of each row
+	colObstructions  [][]int // rows of the obstructions of each column
+}
+
+func NewLab(field *grid.Grid[byte]) *Lab {
+	lab := &Lab{
+		numRows:         field.NumRows(),
+		numCols:         field.NumCols(),
+		obstructed:      newBitset(field.NumRows() * field.NumCols()),
+		rowObstructions: make([][]int, field.NumRows()),
+		colObstructions: make([][]int, field.NumCols()),
+	}
+	// FindAll goes row by row, so both lists come out sorted
+	for _, position := range field.FindAll(Obstruction) {
+		lab.obstructed.set(lab.cell(position))
+		lab.rowObstructions[position.Row] = append(lab.rowObstructions[position.Row], position.Col)
+		lab.colObstructions[position.Col] = append(lab.colObstructions[position.Col], position.Row)
+	}
+	return lab
+}
+
+func (l *Lab) inBounds(p geom.Point) bool {
+	return 0 <= p.Row && p.Row < l.numRows && 0 <= p.Col && p.Col < l.numCols
+}
+
+// cell returns the index of the position in the bitsets.
+func (l *Lab) cell(p geom.Point) int {
+	return p.Row*l.numCols + p.Col
+}
+
+// state returns the index of the position and direction in the bitsets,
+// for the four directions a guard can face.
+func (l *Lab) state(pd PositionDirection) int {
+	return l.cell(pd.pos)*4 + int(pd.dir)/2
+}
+
+// jump moves the guard from pd straight ahead to the cell before the next
+// obstruction, counting extra as one as well. It returns false if there is
+// no obstruction ahead and the guard leaves the lab.
+func (l *Lab) jump(pd PositionDirection, extra geom.Point) (geom.Point, bool) {
+	pos := pd.pos
+	switch pd.dir {
+	case geom.Up, geom.Down:
+		forward := pd.dir == geom.Down
+		row, ok := nearest(l.colObstructions[pos.Col], pos.Row, forward)
+		if extra.Col == pos.Col && ahead(pos.Row, extra.Row, forward) && (!ok || ahead(extra.Row, row, forward)) {
+			row, ok = extra.Row, true
+		}
+		if !ok {
+			return pos, false
+		}
+		return geom.Point{Row: row - pd.dir.Delta().Row, Col: pos.Col}, true
+	default:
+		forward := pd.dir == geom.Right
+		col, ok := nearest(l.rowObstructions[pos.Row], pos.Col, forward)
+		if extra.Row == pos.Row && ahead(pos.Col, extra.Col, forward) && (!ok || ahead(extra.Col, col, forward)) {
+			col, ok = extra.Col, true
+		}
+		if !ok {
+			return pos, false
+		}
+		return geom.Point{Row: pos.Row, Col: col - pd.dir.Delta().Col}, true
+	}
+}
+
+// nearest returns the first of the sorted indices after from, or before it
+// if not forward.
+func nearest(sorted []int, from int, forward bool) (int, bool) {
+	if forward {
+		i := sort.SearchInts(sorted, from+1)
+		if i == len(sorted) {
+			return 0, false
+		}
+		return sorted[i], true
+	}
+	i := sort.SearchInts(sorted, from) - 1
+	if i < 0 {
+		return 0, false
+	}
+	return sorted[i], true
+}
+
+// ahead reports whether b comes after a when moving forward, or before it
+// when moving backward.
+func ahead(a, b int, forward bool) bool {
+	if forward {
+		return b > a
+	}
+	return b < a
+}
+
+// trial is the memory of loops, reused from one trial to the next.
+type trial struct {
+	seen    bitset // states at turns
+	touched []int  // the states set in seen, to clear them afterwards
+}
+
+func (l *Lab) newTrial() *trial {
+	return &trial{seen: newBitset(l.numRows * l.numCols * 4)}
+}
+
+// loops reports whether a guard at pd, with an extra obstruction at extra,
+// patrols forever. Only the states at turns are recorded, so a trial takes
+// about as many steps as the guard turns.
+func (l *Lab) loops(pd PositionDirection, extra geom.Point, t *trial) bool {
+	defer func() {
+		for _, state := range t.touched {
+			t.seen.clear(state)
+		}
+		t.touched = t.touched[:0]
+	}()
+
+	for {
+		stop, ok := l.jump(pd, extra)
+		if !ok {
+			return false
+		}
+		pd = PositionDirection{stop, pd.dir}
+		state := l.state(pd)
+		if t.seen.has(state) {
+			return true
+		}
+		t.seen.set(state)
+		t.touched = append(t.touched, state)
+		pd.dir = pd.dir.TurnRight()
+	}
+}
+
+// LoopInfo describes the cycle of a guard that never leaves the lab.
+type LoopInfo struct {
+	// Entry is the first state of the guard on the cycle.
+	Entry Guard `json:"entry"`
+	// Period is the number of steps, moves and turns, around the cycle.
+	Period int `json:"period"`
+	// Cells and Obstacles are the cells on the cycle and the obstructions
+	// the guard turns at, each once, in the order met from Entry.
+	Cells     []geom.Point `json:"cells"`
+	Obstacles []geom.Point `json:"obstacles"`
+}
+
+// patrol follows the guard, turning right, and calls visit with each cell
+// entered for the first time and the state just before entering it. It
+// returns the number of distinct cells visited and, if the guard never
+// leaves the lab, its loop.
+func (l *Lab) patrol(start Guard, visit func(cell geom.Point, before PositionDirection)) (int, *LoopInfo) {
+	visited := newBitset(l.numRows * l.numCols)
+	visited.set(l.cell(start.Position))
+	count := 1
+
+	before := PositionDirection{start.Position, start.Direction}
+	for step := range l.Simulate([]Guard{start}, TurnRight) {
+		switch step.Event {
+		case Move:
+			if !visited.has(l.cell(step.Position)) {
+				visited.set(l.cell(step.Position))
+				count++
+				if visit != nil {
+					visit(step.Position, before)
+				}
+			}
+		case Exit:
+			return count, nil
+		case LoopDetected:
+			// the first state repeated is where the cycle starts
+			return count, l.loopFrom(Guard{step.Position, step.Direction})
+		}
+		before = PositionDirection{step.Position, step.Direction}
+	}
+	panic("unreachable")
+}
+
+// loopFrom follows the guard once around the cycle starting at entry.
+func (l *Lab) loopFrom(entry Guard) *LoopInfo {
+	info := &LoopInfo{Entry: entry}
+	onCycle := newBitset(l.numRows * l.numCols)
+	turnedAt := newBitset(l.numRows * l.numCols)
+	add := func(set bitset, cells *[]geom.Point, cell geom.Point) {
+		if !set.has(l.cell(cell)) {
+			set.set(l.cell(cell))
+			*cells = append(*cells, cell)
+		}
+	}
+
+	add(onCycle, &info.Cells, entry.Position)
+	direction := entry.Direction
+	for step := range l.Simulate([]Guard{entry}, TurnRight) {
+		switch step.Event {
+		case Move:
+			add(onCycle, &info.Cells, step.Position)
+		case Turn:
+			add(turnedAt, &info.Obstacles, step.Position.Move(direction))
+		case LoopDetected:
+			return info
+		}
+		info.Period++
+		direction = step.Direction
+	}
+	panic("unreachable")
+}
+
+// candidate is a cell where one more obstruction may trap the guard, with
+// the state just before the guard first enters it.
+type candidate struct {
+	cell   geom.Point
+	before PositionDirection
+}
+
+// LoopingObstructions returns the cells where one more obstruction makes the
+// guard patrol forever, in the order the guard first reaches them.
+//
+// Only the cells on the guard's path matter; each is tried from the state
+// just before the guard first enters it, as the path up to there stays the
+// same. The lab itself is never modified: the obstruction is passed to each
+// trial as an overlay, so the trials run on workers goroutines
+// (GOMAXPROCS if not positive). The search stops early with the error of
+// ctx once it is done.
+func (l *Lab) LoopingObstructions(ctx context.Context, start Guard, workers int) ([]geom.Point, error) {
+	var candidates []candidate
+	_, loop := l.patrol(start, func(cell geom.Point, before PositionDirection) {
+		candidates = append(candidates, candidate{cell, before})
+	})
+
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = max(1, min(workers, len(candidates)))
+
+	// each worker takes every workers-th candidate and writes only its own
+	// results, which are then collected in path order
+	loops := make([]bool, len(candidates))
+	var wg sync.WaitGroup
+	for worker := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			t := l.newTrial()
+			for i := worker; i < len(candidates); i += workers {
+				if ctx.Err() != nil {
+					return
+				}
+				loops[i] = l.loops(candidates[i].before, candidates[i].cell, t)
+			}
+		}()
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	positions := []geom.Point{} // empty rather than null in JSON
+	for i, c := range candidates {
+		if loops[i] {
+			positions = append(positions, c.cell)
+		}
+	}
+	if loop != nil {
+		// the guard loops anyway unless the obstruction is on its path
+		positions = append(positions, l.offPath(start, candidates)...)
+	}
+	return positions, nil
+}
+
+// offPath returns the free cells that are neither the start nor on the path
+// given by the candidates, row by row.
+func (l *Lab) offPath(start Guard, candidates []candidate) (cells []geom.Point) {
+	onPath := newBitset(l.numRows * l.numCols)
+	onPath.set(l.cell(start.Position))
+	for _, c := range candidates {
+		onPath.set(l.cell(c.cell))
+	}
+	for row := range l.numRows {
+		for col := range l.numCols {
+			cell := geom.Point{Row: row, Col: col}
+			if !onPath.has(l.cell(cell)) && !l.obstructed.has(l.cell(cell)) {
+				cells = append(cells, cell)
+			}
+		}
+	}
+	return cells
+}
+
+// bitset is a dense set of small non-negative integers.
+type bitset []uint64
+
+func newBitset(size int) bitset {
+	return make(bitset, (size+63)/64)
+}
+
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(i%64)) != 0
+}
+
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b bitset) clear(i int) {
+	b[i/64] &^= 1 << (i % 64)
+}
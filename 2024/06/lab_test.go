@@ -0,0 +1,158 @@
+package day06
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+	"github.com/thonda28/adventofcode/internal/geom"
+)
+
+func newTestSolver(t *testing.T, seed uint64, size int) *Solver {
+	t.Helper()
+
+	solver := &Solver{}
+	if err := solver.Parse(aoctest.Generated(t, generate, seed, aoc.GenOptions{Size: size})); err != nil {
+		t.Fatal(err)
+	}
+	return solver
+}
+
+func TestLoopingObstructionsWorkers(t *testing.T) {
+	for seed := range uint64(5) {
+		s := newTestSolver(t, seed, 200)
+		want, err := s.lab.LoopingObstructions(context.Background(), s.start, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, workers := range []int{0, 2, 3, 16} {
+			got, err := s.lab.LoopingObstructions(context.Background(), s.start, workers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(got, want) {
+				t.Errorf("seed %d, %d workers: %v, want %v", seed, workers, got, want)
+			}
+		}
+	}
+}
+
+func TestLoopingObstructionsCanceled(t *testing.T) {
+	s := newTestSolver(t, 1, 200)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := s.lab.LoopingObstructions(ctx, s.start, 4); !errors.Is(err, context.Canceled) {
+		t.Errorf("err = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestPatrolLoopInfo(t *testing.T) {
+	lab, guards := newTestLab(t, ".#..\n...#\n#...\n.^#.\n")
+	_, loop := lab.patrol(guards[0], nil)
+	if loop == nil {
+		t.Fatal("the guard leaves the lab")
+	}
+
+	want := LoopInfo{
+		Entry:     Guard{geom.Point{Row: 2, Col: 1}, geom.Up},
+		Period:    8,
+		Cells:     []geom.Point{{Row: 2, Col: 1}, {Row: 1, Col: 1}, {Row: 1, Col: 2}, {Row: 2, Col: 2}},
+		Obstacles: []geom.Point{{Row: 0, Col: 1}, {Row: 1, Col: 3}, {Row: 3, Col: 2}, {Row: 2, Col: 0}},
+	}
+	if loop.Entry != want.Entry || loop.Period != want.Period ||
+		!slices.Equal(loop.Cells, want.Cells) || !slices.Equal(loop.Obstacles, want.Obstacles) {
+		t.Errorf("loop = %+v, want %+v", *loop, want)
+	}
+}
+
+func TestReportReusesAnswers(t *testing.T) {
+	s := newTestSolver(t, 1, 50)
+	visited, err := s.Part1()
+	if err != nil {
+		t.Fatal(err)
+	}
+	count, err := s.Part2()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// without a lab, Report can only return what the parts found
+	s.lab = nil
+	patrol, err := s.Report(1)
+	if err != nil || patrol.(PatrolReport).Visited != visited {
+		t.Errorf("Report(1) = %v, %v, want %d visited", patrol, err, visited)
+	}
+	obstructions, err := s.Report(2)
+	if err != nil || len(obstructions.(ObstructionReport).Obstructions) != count {
+		t.Errorf("Report(2) = %v, %v, want %d obstructions", obstructions, err, count)
+	}
+}
+
+// TestLoopingObstructionsOnLoopingPath covers maps where the guard loops
+// without any new obstruction, which the generator does not write.
+func TestLoopingObstructionsOnLoopingPath(t *testing.T) {
+	tested := 0
+	for seed := range uint64(300) {
+		rng := aoc.NewRand(seed)
+		var b strings.Builder
+		for row := range 10 {
+			for col := range 10 {
+				switch {
+				case row == 5 && col == 5:
+					b.WriteByte(StartMarkers[rng.IntN(len(StartMarkers))])
+				case rng.IntN(5) == 0:
+					b.WriteByte(Obstruction)
+				default:
+					b.WriteByte(NoObstruction)
+				}
+			}
+			b.WriteByte('\n')
+		}
+		field := b.String()
+
+		lab, guards := newTestLab(t, field)
+		if _, loop := lab.patrol(guards[0], nil); loop == nil {
+			continue
+		}
+		tested++
+
+		positions, err := lab.LoopingObstructions(context.Background(), guards[0], 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ref := &referenceSolver{}
+		if err := ref.Parse(strings.NewReader(field)); err != nil {
+			t.Fatal(err)
+		}
+		if want, _ := ref.Part2(); len(positions) != want {
+			t.Errorf("%d looping obstructions, reference %d, in\n%s", len(positions), want, field)
+		}
+	}
+	if tested == 0 {
+		t.Error("no map where the guard loops")
+	}
+}
+
+func TestReportWithoutLoopingObstructions(t *testing.T) {
+	s := &Solver{}
+	if err := s.Parse(strings.NewReader(".....\n..^..\n.....\n")); err != nil {
+		t.Fatal(err)
+	}
+	report, err := s.Report(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(report)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"obstructions":[]}`; string(data) != want {
+		t.Errorf("report = %s, want %s", data, want)
+	}
+}
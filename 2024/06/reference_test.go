@@ -0,0 +1,88 @@
+package day06
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+// referenceSolver is a deliberately naive solver to test Solver against.
+// It walks the guard one step at a time and calls it a loop once the guard
+// has taken more steps than there are states.
+type referenceSolver struct {
+	rows [][]byte
+}
+
+func (s *referenceSolver) Parse(r io.Reader) error {
+	var err error
+	s.rows, err = input.ByteGrid(r)
+	return err
+}
+
+// walk returns the number of distinct cells the guard visits, or false if
+// it never leaves.
+func (s *referenceSolver) walk() (int, bool) {
+	var row, col, direction int
+	for r := range s.rows {
+		for c := range s.rows[r] {
+			if i := strings.IndexByte(StartMarkers, s.rows[r][c]); i >= 0 {
+				row, col, direction = r, c, i
+			}
+		}
+	}
+
+	// in the order of StartMarkers, turning right
+	deltas := [][2]int{{-1, 0}, {0, 1}, {1, 0}, {0, -1}}
+	visited := map[[2]int]bool{{row, col}: true}
+	maxSteps := 4 * len(s.rows) * len(s.rows[0])
+	for step := 0; step <= maxSteps; step++ {
+		nextRow, nextCol := row+deltas[direction][0], col+deltas[direction][1]
+		if nextRow < 0 || nextRow >= len(s.rows) || nextCol < 0 || nextCol >= len(s.rows[nextRow]) {
+			return len(visited), true
+		}
+		if s.rows[nextRow][nextCol] == Obstruction {
+			direction = (direction + 1) % 4
+			continue
+		}
+		row, col = nextRow, nextCol
+		visited[[2]int{row, col}] = true
+	}
+	return 0, false
+}
+
+func (s *referenceSolver) Part1() (int, error) {
+	count, ok := s.walk()
+	if !ok {
+		return 0, errors.New("cannot exit this field")
+	}
+	return count, nil
+}
+
+func (s *referenceSolver) Part2() (count int, err error) {
+	for r := range s.rows {
+		for c := range s.rows[r] {
+			if s.rows[r][c] != NoObstruction {
+				continue
+			}
+			s.rows[r][c] = Obstruction
+			if _, ok := s.walk(); !ok {
+				count++
+			}
+			s.rows[r][c] = NoObstruction
+		}
+	}
+	return count, nil
+}
+
+func TestReference(t *testing.T) {
+	aoctest.Differential(t, 2024, 6, func() aoc.Solver { return &referenceSolver{} }, aoctest.DiffOptions{
+		Inputs:   200,
+		Gen:      aoc.GenOptions{Size: 24},
+		Simplify: map[byte]byte{Obstruction: NoObstruction},
+	})
+}
@@ -0,0 +1,160 @@
+package day06
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/thonda28/adventofcode/internal/geom"
+	"github.com/thonda28/adventofcode/internal/grid"
+)
+
+// StartMarkers mark a guard facing up, right, down or left.
+const StartMarkers = "^>v<"
+
+// Guard is a guard on the map and the direction it faces.
+type Guard struct {
+	Position  geom.Point     `json:"position"`
+	Direction geom.Direction `json:"direction"`
+}
+
+// FindGuards returns the guards marked on the field, row by row.
+func FindGuards(field *grid.Grid[byte]) (guards []Guard) {
+	for _, position := range field.FindFunc(isStartMarker) {
+		direction, _ := geom.ParseDirection(rune(field.Get(position)))
+		guards = append(guards, Guard{position, direction})
+	}
+	return guards
+}
+
+func isStartMarker(cell byte) bool {
+	return strings.IndexByte(StartMarkers, cell) >= 0
+}
+
+// TurnRule is how a guard turns in front of an obstruction.
+type TurnRule int
+
+const (
+	TurnRight TurnRule = iota // the puzzle's rule
+	TurnLeft
+	TurnAround
+)
+
+func (r TurnRule) turn(d geom.Direction) geom.Direction {
+	switch r {
+	case TurnLeft:
+		return d.TurnLeft()
+	case TurnAround:
+		return d.Reverse()
+	default:
+		return d.TurnRight()
+	}
+}
+
+func (r TurnRule) String() string {
+	switch r {
+	case TurnRight:
+		return "right"
+	case TurnLeft:
+		return "left"
+	case TurnAround:
+		return "reverse"
+	default:
+		return fmt.Sprintf("TurnRule(%d)", int(r))
+	}
+}
+
+// Event is what happened in a step of a guard.
+type Event int
+
+const (
+	Move Event = iota
+	Turn
+	Exit         // the guard left the map
+	LoopDetected // the guard is back in a state it was in before
+)
+
+func (e Event) String() string {
+	switch e {
+	case Move:
+		return "move"
+	case Turn:
+		return "turn"
+	case Exit:
+		return "exit"
+	case LoopDetected:
+		return "loop-detected"
+	default:
+		return fmt.Sprintf("Event(%d)", int(e))
+	}
+}
+
+// Step is a step of a guard: the guard's index in the guards simulated,
+// its position and direction after the step and what happened.
+type Step struct {
+	Guard     int
+	Position  geom.Point
+	Direction geom.Direction
+	Event     Event
+}
+
+func (s Step) String() string {
+	return fmt.Sprintf("guard %d %s at %v facing %v", s.Guard, s.Event, s.Position, s.Direction)
+}
+
+// Simulate yields the steps of the guards, which move simultaneously: in
+// each round every guard still patrolling takes one step, in the order of
+// guards. Guards do not block each other. A guard stops after its Exit
+// step, with the position it left from, or after the LoopDetected step
+// following the step that brought it back to a state it was in before.
+func (l *Lab) Simulate(guards []Guard, rule TurnRule) iter.Seq[Step] {
+	return func(yield func(Step) bool) {
+		states := make([]PositionDirection, len(guards))
+		seen := make([]bitset, len(guards))
+		for i, guard := range guards {
+			states[i] = PositionDirection{guard.Position, guard.Direction}
+			seen[i] = newBitset(l.numRows * l.numCols * 4)
+			seen[i].set(l.state(states[i]))
+		}
+
+		stopped := make([]bool, len(guards))
+		for patrolling := len(guards); patrolling > 0; {
+			for i := range guards {
+				if stopped[i] {
+					continue
+				}
+
+				pd := &states[i]
+				next := pd.pos.Move(pd.dir)
+				event := Move
+				switch {
+				case !l.inBounds(next):
+					event = Exit
+				case l.obstructed.has(l.cell(next)):
+					pd.dir = rule.turn(pd.dir)
+					event = Turn
+				default:
+					pd.pos = next
+				}
+				if !yield(Step{i, pd.pos, pd.dir, event}) {
+					return
+				}
+
+				if event == Exit {
+					stopped[i] = true
+					patrolling--
+					continue
+				}
+				if state := l.state(*pd); seen[i].has(state) {
+					stopped[i] = true
+					patrolling--
+					if !yield(Step{i, pd.pos, pd.dir, LoopDetected}) {
+						return
+					}
+				} else {
+					seen[i].set(state)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package day06
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/geom"
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+func newTestLab(t *testing.T, field string) (*Lab, []Guard) {
+	t.Helper()
+
+	g, err := input.Grid(strings.NewReader(field))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return NewLab(g), FindGuards(g)
+}
+
+func TestFindGuards(t *testing.T) {
+	_, guards := newTestLab(t, "^.>\n...\nv.<\n")
+	want := []Guard{
+		{geom.Point{Row: 0, Col: 0}, geom.Up},
+		{geom.Point{Row: 0, Col: 2}, geom.Right},
+		{geom.Point{Row: 2, Col: 0}, geom.Down},
+		{geom.Point{Row: 2, Col: 2}, geom.Left},
+	}
+	if !slices.Equal(guards, want) {
+		t.Errorf("FindGuards = %v, want %v", guards, want)
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	tests := []struct {
+		name  string
+		field string
+		rule  TurnRule
+		want  []string
+	}{
+		{
+			name:  "exit",
+			field: ".#.\n...\n.^.\n",
+			rule:  TurnRight,
+			want:  []string{"0 move (1,1)", "0 turn (1,1)", "0 move (1,2)", "0 exit (1,2)"},
+		},
+		{
+			name:  "left",
+			field: ".#.\n...\n.^.\n",
+			rule:  TurnLeft,
+			want:  []string{"0 move (1,1)", "0 turn (1,1)", "0 move (1,0)", "0 exit (1,0)"},
+		},
+		{
+			name:  "reverse",
+			field: "#\n.\n^\n#\n",
+			rule:  TurnAround,
+			want:  []string{"0 move (1,0)", "0 turn (1,0)", "0 move (2,0)", "0 turn (2,0)", "0 loop-detected (2,0)"},
+		},
+		{
+			name:  "simultaneous",
+			field: ">..\n..<\n",
+			rule:  TurnRight,
+			want:  []string{"0 move (0,1)", "1 move (1,1)", "0 move (0,2)", "1 move (1,0)", "0 exit (0,2)", "1 exit (1,0)"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lab, guards := newTestLab(t, tt.field)
+			var got []string
+			for step := range lab.Simulate(guards, tt.rule) {
+				got = append(got, fmt.Sprintf("%d %v (%d,%d)", step.Guard, step.Event, step.Position.Row, step.Position.Col))
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("steps = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
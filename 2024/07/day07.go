@@ -1,13 +1,14 @@
-package main
+package day07
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
-	"log"
-	"os"
-	"strconv"
-	"strings"
+	"io"
+	"math"
+	"slices"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
 )
 
 type Candidate struct {
@@ -15,106 +16,161 @@ type Candidate struct {
 	Terms  []int
 }
 
-func parseInputFile(filename string) (candidates []Candidate) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		fields := strings.Split(scanner.Text(), ":")
-		answer, err := strconv.Atoi(fields[0])
-		if err != nil {
-			log.Fatal(err)
-		}
-		terms := []int{}
-		for _, term := range strings.Fields(strings.TrimSpace(fields[1])) {
-			term, err := strconv.Atoi(term)
-			if err != nil {
-				log.Fatal(err)
-			}
-			terms = append(terms, term)
-		}
-
-		candidates = append(candidates, Candidate{answer, terms})
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-	return candidates
+func parseInputFile(r io.Reader) (candidates []Candidate, err error) {
+	return input.Parse(r, func(text string) (Candidate, error) {
+		answer, terms, err := input.KeyValues(text)
+		return Candidate{answer, terms}, err
+	})
 }
 
-func canSolve(answer int, terms []int, operators []string) bool {
+func canSolve(answer int, terms []int, operators []string) (bool, error) {
 	if len(terms) == 0 {
-		return false
+		return false, nil
+	}
+	if err := checkNonNegative(answer, terms); err != nil {
+		return false, err
 	}
 
-	var canSolveHelper func(currentValue, answer int, terms []int) bool
-	canSolveHelper = func(currentValue, answer int, terms []int) bool {
+	var canSolveHelper func(currentValue, answer int, terms []int) (bool, error)
+	canSolveHelper = func(currentValue, answer int, terms []int) (bool, error) {
 		if len(terms) == 0 {
-			return currentValue == answer
+			return currentValue == answer, nil
 		}
 
 		for _, op := range operators {
 			nextValue, err := calculate(currentValue, terms[0], op)
+			if errors.Is(err, errOverflow) {
+				// the value stays past any answer until it is multiplied
+				// by a zero term, whatever the operators in between
+				for i, term := range terms[1:] {
+					if term != 0 || !slices.Contains(operators, "*") {
+						continue
+					}
+					if ok, err := canSolveHelper(0, answer, terms[i+2:]); ok || err != nil {
+						return ok, err
+					}
+				}
+				continue
+			}
 			if err != nil {
-				log.Fatal(err)
+				return false, err
 			}
 
-			if canSolveHelper(nextValue, answer, terms[1:]) {
-				return true
+			if ok, err := canSolveHelper(nextValue, answer, terms[1:]); ok || err != nil {
+				return ok, err
 			}
 		}
-		return false
+		return false, nil
 	}
 
 	return canSolveHelper(terms[0], answer, terms[1:])
 }
 
+// checkNonNegative rejects negative numbers, which the operators are not
+// defined for.
+func checkNonNegative(answer int, terms []int) error {
+	if answer < 0 {
+		return fmt.Errorf("negative answer %d", answer)
+	}
+	for _, term := range terms {
+		if term < 0 {
+			return fmt.Errorf("negative term %d", term)
+		}
+	}
+	return nil
+}
+
+var errOverflow = errors.New("overflow")
+
+// calculate applies the operator to non-negative a and b, failing with
+// errOverflow if the result does not fit in an int.
 func calculate(a, b int, op string) (int, error) {
 	switch op {
 	case "+":
+		if a > math.MaxInt-b {
+			return 0, errOverflow
+		}
 		return a + b, nil
 	case "*":
+		if b != 0 && a > math.MaxInt/b {
+			return 0, errOverflow
+		}
 		return a * b, nil
 	case "||":
-		concatnated := strconv.Itoa(a) + strconv.Itoa(b)
-		concatnatedInt, err := strconv.Atoi(concatnated)
-		if err != nil {
-			return 0, err
+		shift, ok := pow10(digits(b))
+		if !ok || a > (math.MaxInt-b)/shift {
+			if a == 0 {
+				return b, nil
+			}
+			return 0, errOverflow
 		}
-		return concatnatedInt, nil
+		return a*shift + b, nil
 	default:
 		return 0, errors.New("invalid operator")
 	}
 }
 
-func main() {
-	// const filename = "day07.example"
-	const filename = "day07.input"
+// digits returns the number of decimal digits of non-negative n.
+func digits(n int) int {
+	count := 1
+	for ; n >= 10; n /= 10 {
+		count++
+	}
+	return count
+}
 
-	candidates := parseInputFile(filename)
+// pow10 returns 10 to the n, or false if it overflows.
+func pow10(n int) (int, bool) {
+	p := 1
+	for range n {
+		if p > math.MaxInt/10 {
+			return 0, false
+		}
+		p *= 10
+	}
+	return p, true
+}
 
-	// Part 1
+type Solver struct {
+	candidates []Candidate
+}
+
+func init() {
+	aoc.Register(2024, 7, func() aoc.Solver { return &Solver{} })
+}
+
+func (s *Solver) Parse(r io.Reader) error {
+	var err error
+	s.candidates, err = parseInputFile(r)
+	return err
+}
+
+func (s *Solver) Part1() (int, error) {
 	twoAvailableOperators := []string{"+", "*"}
 	totalCalibrationResult := 0
-	for _, candidate := range candidates {
-		if canSolve(candidate.Answer, candidate.Terms, twoAvailableOperators) {
+	for _, candidate := range s.candidates {
+		ok, err := canSolveReverse(candidate.Answer, candidate.Terms, twoAvailableOperators)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
 			totalCalibrationResult += candidate.Answer
 		}
 	}
-	fmt.Printf("totalCalibrationResult: %d\n", totalCalibrationResult)
+	return totalCalibrationResult, nil
+}
 
-	// Part 2
+func (s *Solver) Part2() (int, error) {
 	threeAvailableOperators := []string{"+", "*", "||"}
 	newTotalCalibrationResult := 0
-	for _, candidate := range candidates {
-		if canSolve(candidate.Answer, candidate.Terms, threeAvailableOperators) {
+	for _, candidate := range s.candidates {
+		ok, err := canSolveReverse(candidate.Answer, candidate.Terms, threeAvailableOperators)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
 			newTotalCalibrationResult += candidate.Answer
 		}
 	}
-	fmt.Printf("newTotalCalibrationResult: %d\n", newTotalCalibrationResult)
+	return newTotalCalibrationResult, nil
 }
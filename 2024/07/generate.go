@@ -0,0 +1,75 @@
+package day07
+
+import (
+	"io"
+	"math/rand/v2"
+	"strconv"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 7, generate)
+}
+
+// maxGeneratedAnswer keeps generated answers about as large as the real
+// ones, far from overflowing.
+const maxGeneratedAnswer = 1_000_000_000_000_000
+
+// maxTermDigits bounds the digits of all terms of an equation together, so
+// that even concatenating every term does not overflow while searching.
+// Equations longer than that use one-digit terms, whose concatenation may
+// overflow; the solvers detect it.
+const maxTermDigits = 18
+
+// generate writes opts.Size equations of 2 to opts.Length terms of up to
+// three digits. Each answer is computed with random operators, preferring
+// + where another operator would exceed maxGeneratedAnswer, and about a
+// third of the answers are then changed so that most of those cannot be
+// solved.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 850, Length: 12})
+	maxTerms := max(2, opts.Length)
+	operators := []string{"+", "*", "||"}
+
+	var buf []byte
+	for range opts.Size {
+		terms := make([]int, 2+rng.IntN(maxTerms-1))
+		digitsLeft := maxTermDigits
+		for i := range terms {
+			maxDigits := min(3, max(1, digitsLeft-(len(terms)-i-1)))
+			digits := 1 + rng.IntN(maxDigits)
+			digitsLeft -= digits
+
+			low := 1
+			for range digits - 1 {
+				low *= 10
+			}
+			terms[i] = low + rng.IntN(9*low)
+		}
+
+		answer := terms[0]
+		for _, term := range terms[1:] {
+			next, err := calculate(answer, term, operators[rng.IntN(len(operators))])
+			if err != nil || next > maxGeneratedAnswer {
+				next = answer + term
+			}
+			answer = next
+		}
+		if rng.IntN(3) == 0 {
+			answer += 1 + rng.IntN(100)
+		}
+
+		buf = strconv.AppendInt(buf[:0], int64(answer), 10)
+		buf = append(buf, ':')
+		for _, term := range terms {
+			buf = append(buf, ' ')
+			buf = strconv.AppendInt(buf, int64(term), 10)
+		}
+		buf = append(buf, '\n')
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
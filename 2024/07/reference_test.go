@@ -0,0 +1,77 @@
+package day07
+
+import (
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+// referenceSolver is a deliberately naive solver to test Solver against.
+// It evaluates every combination of operators, with big integers so that
+// nothing can overflow.
+type referenceSolver struct {
+	candidates []Candidate
+}
+
+func (s *referenceSolver) Parse(r io.Reader) error {
+	var err error
+	s.candidates, err = parseInputFile(r)
+	return err
+}
+
+// evaluates reports whether some combination of the first numOperators
+// of "+", "*" and "||" gives the answer.
+func evaluates(candidate Candidate, numOperators int) bool {
+	answer := big.NewInt(int64(candidate.Answer))
+	combinations := 1
+	for range len(candidate.Terms) - 1 {
+		combinations *= numOperators
+	}
+
+	for combination := range combinations {
+		value := big.NewInt(int64(candidate.Terms[0]))
+		for _, term := range candidate.Terms[1:] {
+			operand := big.NewInt(int64(term))
+			switch combination % numOperators {
+			case 0:
+				value.Add(value, operand)
+			case 1:
+				value.Mul(value, operand)
+			case 2:
+				value.SetString(value.String()+operand.String(), 10)
+			}
+			combination /= numOperators
+		}
+		if value.Cmp(answer) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *referenceSolver) sum(numOperators int) (total int) {
+	for _, candidate := range s.candidates {
+		if evaluates(candidate, numOperators) {
+			total += candidate.Answer
+		}
+	}
+	return total
+}
+
+func (s *referenceSolver) Part1() (int, error) {
+	return s.sum(2), nil
+}
+
+func (s *referenceSolver) Part2() (int, error) {
+	return s.sum(3), nil
+}
+
+func TestReference(t *testing.T) {
+	aoctest.Differential(t, 2024, 7, func() aoc.Solver { return &referenceSolver{} }, aoctest.DiffOptions{
+		Inputs: 200,
+		Gen:    aoc.GenOptions{Size: 20, Length: 7},
+	})
+}
@@ -0,0 +1,70 @@
+package day07
+
+import "errors"
+
+// canSolveReverse reports, like canSolve, whether the operators combine the
+// terms into the answer, but works backwards from the answer: the last term
+// is peeled off by undoing each operator, which only works when the rest is
+// a non-negative whole number. Subtracting must not go below zero, dividing
+// must be exact and unconcatenating needs the answer to end in the term's
+// digits, so most branches are pruned right away instead of being followed
+// through every remaining term. Answer and terms must not be negative.
+func canSolveReverse(answer int, terms []int, operators []string) (bool, error) {
+	if len(terms) == 0 {
+		return false, nil
+	}
+	if err := checkNonNegative(answer, terms); err != nil {
+		return false, err
+	}
+	for _, op := range operators {
+		if op != "+" && op != "*" && op != "||" {
+			return false, errors.New("invalid operator")
+		}
+	}
+
+	var canSolveHelper func(target int, terms []int) bool
+	canSolveHelper = func(target int, terms []int) bool {
+		last := len(terms) - 1
+		if last == 0 {
+			return target == terms[0]
+		}
+
+		term := terms[last]
+		for _, op := range operators {
+			if op == "*" && term == 0 && target == 0 {
+				// zero times whatever the other terms give
+				return true
+			}
+			rest, ok := uncalculate(target, term, op)
+			if ok && canSolveHelper(rest, terms[:last]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	return canSolveHelper(answer, terms), nil
+}
+
+// uncalculate returns the a for which calculate(a, b, op) is the target,
+// or false if there is none or, for a zero b and "*", any.
+func uncalculate(target, b int, op string) (int, bool) {
+	switch op {
+	case "+":
+		return target - b, target >= b
+	case "*":
+		if b == 0 {
+			return 0, false
+		}
+		return target / b, target%b == 0
+	case "||":
+		shift, ok := pow10(digits(b))
+		if !ok {
+			// b has as many digits as the largest int, only 0 || b fits
+			return 0, target == b
+		}
+		return target / shift, target%shift == b
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,95 @@
+package day07
+
+import (
+	"math"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+var searches = []struct {
+	name  string
+	solve func(answer int, terms []int, operators []string) (bool, error)
+}{
+	{"forward", canSolve},
+	{"reverse", canSolveReverse},
+}
+
+func TestReverseMatchesReference(t *testing.T) {
+	// small numbers, zeros included, so that some equations are solvable
+	aoctest.Quick(t, func(answer uint8, smallTerms []uint8) bool {
+		if len(smallTerms) == 0 {
+			return true
+		}
+		candidate := Candidate{Answer: int(answer % 64)}
+		for _, term := range smallTerms[:min(len(smallTerms), 6)] {
+			candidate.Terms = append(candidate.Terms, int(term%12))
+		}
+
+		for numOperators, operators := range map[int][]string{2: {"+", "*"}, 3: {"+", "*", "||"}} {
+			ok, err := canSolveReverse(candidate.Answer, candidate.Terms, operators)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ok != evaluates(candidate, numOperators) {
+				t.Logf("%v with %v: %v", candidate, operators, ok)
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestOverflow(t *testing.T) {
+	twoOperators := []string{"+", "*"}
+	threeOperators := []string{"+", "*", "||"}
+	tests := []struct {
+		answer    int
+		terms     []int
+		operators []string
+		want      bool
+	}{
+		{math.MaxInt, []int{math.MaxInt / 10, 7}, threeOperators, true},
+		{math.MaxInt, []int{math.MaxInt - 1, 1}, threeOperators, true},
+		{math.MaxInt, []int{0, math.MaxInt}, threeOperators, true},
+		{1, []int{math.MaxInt, 2, 1}, threeOperators, false},
+		{1, []int{math.MaxInt / 10, 8, 1}, threeOperators, false},
+		// multiplying by zero brings an overflowed value back
+		{0, []int{math.MaxInt, 2, 0}, twoOperators, true},
+		{3, []int{math.MaxInt, 9, 0, 3}, threeOperators, true},
+		{3, []int{math.MaxInt, 9, 1, 3}, threeOperators, false},
+		{0, []int{math.MaxInt, 2, 0}, []string{"+"}, false},
+	}
+	for _, tt := range tests {
+		for _, search := range searches {
+			ok, err := search.solve(tt.answer, tt.terms, tt.operators)
+			if err != nil || ok != tt.want {
+				t.Errorf("%s(%d, %v, %v) = %v, %v, want %v", search.name, tt.answer, tt.terms, tt.operators, ok, err, tt.want)
+			}
+		}
+	}
+}
+
+// BenchmarkCanSolve compares the forward and the reverse search on long
+// generated equations, where the forward search tries up to 3^12
+// combinations for each.
+func BenchmarkCanSolve(b *testing.B) {
+	candidates, err := parseInputFile(aoctest.Generated(b, generate, 1, aoc.GenOptions{Size: 20, Length: 13}))
+	if err != nil {
+		b.Fatal(err)
+	}
+	operators := []string{"+", "*", "||"}
+
+	for _, search := range searches {
+		b.Run(search.name, func(b *testing.B) {
+			for range b.N {
+				for _, candidate := range candidates {
+					if _, err := search.solve(candidate.Answer, candidate.Terms, operators); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
@@ -1,111 +1,74 @@
-package main
+package day08
 
 import (
-	"bufio"
-	"fmt"
-	"log"
-	"os"
+	"io"
 	"unicode"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/geom"
+	"github.com/thonda28/adventofcode/internal/grid"
+	"github.com/thonda28/adventofcode/internal/input"
+	"github.com/thonda28/adventofcode/internal/validate"
 )
 
-type Position struct {
-	Row, Col int
+func parseInputFile(r io.Reader) (field *grid.Grid[byte], err error) {
+	return input.Grid(r)
 }
 
-func parseInputFile(filename string) (field []string) {
-	file, err := os.Open(filename)
-	if err != nil {
-		log.Fatal(err)
-	}
-	defer file.Close()
-
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		field = append(field, scanner.Text())
-	}
-
-	if err := scanner.Err(); err != nil {
-		log.Fatal(err)
-	}
-	return field
-}
-
-func getAntennaPositions(field []string) (antennaPositions map[byte][]Position) {
-	numRows := len(field)
-	numCols := len(field[0])
-
+func getAntennaPositions(field *grid.Grid[byte]) (antennaPositions map[byte][]geom.Point) {
 	var isAntenna = func(b byte) bool {
 		r := rune(b)
 		return unicode.IsLetter(r) || unicode.IsDigit(r)
 	}
 
-	antennaPositions = make(map[byte][]Position)
-	for row := 0; row < numRows; row++ {
-		for col := 0; col < numCols; col++ {
-			antenna := field[row][col]
-			if !isAntenna(antenna) {
-				continue
-			}
-
-			position := Position{row, col}
-			antennaPositions[antenna] = append(antennaPositions[antenna], position)
+	antennaPositions = make(map[byte][]geom.Point)
+	for position, antenna := range field.All() {
+		if !isAntenna(antenna) {
+			continue
 		}
+		antennaPositions[antenna] = append(antennaPositions[antenna], position)
 	}
 
 	return antennaPositions
 }
 
 func getAntinodes(
-	field []string,
-	positions []Position,
+	field *grid.Grid[byte],
+	positions []geom.Point,
 	isExtendedMode bool,
-) (antinodePositions map[Position]struct{}) {
-	numRows := len(field)
-	numCols := len(field[0])
-
-	isInside := func(row, col int) bool {
-		return 0 <= row && row < numRows && 0 <= col && col < numCols
-	}
-
-	antinodePositions = make(map[Position]struct{})
+) (antinodePositions map[geom.Point]struct{}) {
+	antinodePositions = make(map[geom.Point]struct{})
 	for i := 0; i < len(positions); i++ {
 		posA := positions[i]
 		for j := i + 1; j < len(positions); j++ {
 			posB := positions[j]
 
-			deltaRow := posA.Row - posB.Row
-			deltaCol := posA.Col - posB.Col
+			delta := posA.Sub(posB)
 
 			if isExtendedMode {
 				// Part 2
 
-				// antinode candidate on A’s side
-				candidateA := posA
-				for isInside(candidateA.Row, candidateA.Col) {
-					antinodePositions[candidateA] = struct{}{}
-					candidateA.Row += deltaRow
-					candidateA.Col += deltaCol
+				// every grid position in line with A and B, walking from A
+				// towards A’s side and then towards B’s side
+				step := delta.Step()
+				for candidate := posA; field.InBounds(candidate); candidate = candidate.Add(step) {
+					antinodePositions[candidate] = struct{}{}
 				}
-
-				// antinode candidate on B’s side
-				candidateB := posB
-				for isInside(candidateB.Row, candidateB.Col) {
-					antinodePositions[candidateB] = struct{}{}
-					candidateB.Row -= deltaRow
-					candidateB.Col -= deltaCol
+				for candidate := posA.Sub(step); field.InBounds(candidate); candidate = candidate.Sub(step) {
+					antinodePositions[candidate] = struct{}{}
 				}
 			} else {
 				// Part 1
 
 				// antinode candidate on A’s side
-				candidateA := Position{posA.Row + deltaRow, posA.Col + deltaCol}
-				if isInside(candidateA.Row, candidateA.Col) {
+				candidateA := posA.Add(delta)
+				if field.InBounds(candidateA) {
 					antinodePositions[candidateA] = struct{}{}
 				}
 
 				// antinode candidate on B’s side
-				candidateB := Position{posB.Row - deltaRow, posB.Col - deltaCol}
-				if isInside(candidateB.Row, candidateB.Col) {
+				candidateB := posB.Sub(delta)
+				if field.InBounds(candidateB) {
 					antinodePositions[candidateB] = struct{}{}
 				}
 			}
@@ -115,19 +78,19 @@ func getAntinodes(
 }
 
 func getAllAntinodes(
-	field []string,
-	antennaPositions map[byte][]Position,
+	field *grid.Grid[byte],
+	antennaPositions map[byte][]geom.Point,
 	isExtendedMode bool,
-) (allAntinodePositions map[Position]struct{}) {
-	allAntinodePositions = make(map[Position]struct{})
+) (allAntinodePositions map[geom.Point]struct{}) {
+	allAntinodePositions = make(map[geom.Point]struct{})
 	for _, positions := range antennaPositions {
 		allAntinodePositions = union(allAntinodePositions, getAntinodes(field, positions, isExtendedMode))
 	}
 	return allAntinodePositions
 }
 
-func union(set1, set2 map[Position]struct{}) map[Position]struct{} {
-	result := make(map[Position]struct{})
+func union(set1, set2 map[geom.Point]struct{}) map[geom.Point]struct{} {
+	result := make(map[geom.Point]struct{})
 
 	for key := range set1 {
 		result[key] = struct{}{}
@@ -140,18 +103,35 @@ func union(set1, set2 map[Position]struct{}) map[Position]struct{} {
 	return result
 }
 
-func main() {
-	// const filename = "day08.example"
-	const filename = "day08.input"
+type Solver struct {
+	field            *grid.Grid[byte]
+	antennaPositions map[byte][]geom.Point
+}
+
+func init() {
+	aoc.Register(2024, 8, func() aoc.Solver { return &Solver{} })
+}
 
-	field := parseInputFile(filename)
+func (s *Solver) Parse(r io.Reader) error {
+	var err error
+	s.field, err = parseInputFile(r)
+	if err != nil {
+		return err
+	}
+	s.antennaPositions = getAntennaPositions(s.field)
+	return nil
+}
 
-	// Part 1
-	antennaPositions := getAntennaPositions(field)
-	allAntinodes := getAllAntinodes(field, antennaPositions, false)
-	fmt.Printf("the number of antinodes: %d\n", len(allAntinodes))
+func (s *Solver) Invariants() []validate.Invariant {
+	return []validate.Invariant{validate.Rectangular()}
+}
+
+func (s *Solver) Part1() (int, error) {
+	allAntinodes := getAllAntinodes(s.field, s.antennaPositions, false)
+	return len(allAntinodes), nil
+}
 
-	// Part 2
-	allExtendedAntinodes := getAllAntinodes(field, antennaPositions, true)
-	fmt.Printf("the number of extended antinodes: %d\n", len(allExtendedAntinodes))
+func (s *Solver) Part2() (int, error) {
+	allExtendedAntinodes := getAllAntinodes(s.field, s.antennaPositions, true)
+	return len(allExtendedAntinodes), nil
 }
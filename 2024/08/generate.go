@@ -0,0 +1,45 @@
+package day08
+
+import (
+	"io"
+	"math/rand/v2"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func init() {
+	aoc.RegisterGenerator(2024, 8, generate)
+}
+
+const frequencies = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// generate writes an opts.Size x opts.Size map where about 2% of the cells
+// hold an antenna, with about four antennas per frequency while there are
+// frequencies left.
+func generate(w io.Writer, rng *rand.Rand, opts aoc.GenOptions) error {
+	opts = opts.Or(aoc.GenOptions{Size: 50})
+	size := opts.Size
+
+	rows := make([][]byte, size)
+	for row := range rows {
+		rows[row] = make([]byte, size+1)
+		for col := range size {
+			rows[row][col] = '.'
+		}
+		rows[row][size] = '\n'
+	}
+
+	antennas := size * size / 50
+	numFrequencies := min(len(frequencies), max(1, antennas/4))
+	for range antennas {
+		row, col := rng.IntN(size), rng.IntN(size)
+		rows[row][col] = frequencies[rng.IntN(numFrequencies)]
+	}
+
+	for _, row := range rows {
+		if _, err := w.Write(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,27 @@
+package day08
+
+import (
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+func TestExtendedAntinodesIncludeAntinodes(t *testing.T) {
+	aoctest.Quick(t, func(seed uint64, size uint8) bool {
+		r := aoctest.Generated(t, generate, seed, aoc.GenOptions{Size: 1 + int(size)%40})
+		field, err := parseInputFile(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		antennaPositions := getAntennaPositions(field)
+		extended := getAllAntinodes(field, antennaPositions, true)
+		for position := range getAllAntinodes(field, antennaPositions, false) {
+			if _, ok := extended[position]; !ok {
+				return false
+			}
+		}
+		return true
+	})
+}
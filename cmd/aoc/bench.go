@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"testing"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aoctest"
+)
+
+// benchResult is a measurement of one step, as saved in a baseline file.
+type benchResult struct {
+	NsPerOp     int64 `json:"ns_per_op"`
+	AllocsPerOp int64 `json:"allocs_per_op"`
+	BytesPerOp  int64 `json:"bytes_per_op"`
+}
+
+// baseline maps "2024/06 Part2" to its measurement.
+type baseline map[string]benchResult
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ContinueOnError)
+	input := fs.String("input", "input", `input to solve: "example", "input" or a file path`)
+	save := fs.String("save", "", "save the results as a baseline to this file")
+	baselineFile := fs.String("baseline", "", "compare the results with a baseline saved by --save")
+	threshold := fs.Float64("threshold", 1.2, "flag a step as regressed if slower than baseline times this")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	var base baseline
+	if *baselineFile != "" {
+		data, err := os.ReadFile(*baselineFile)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(data, &base); err != nil {
+			return fmt.Errorf("%s: %w", *baselineFile, err)
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "puzzle\tstep\ttime/op\tallocs/op\tbytes/op\tbaseline\t")
+
+	results := make(baseline)
+	regressions := 0
+	for _, puzzle := range puzzles {
+		filename := inputFile(puzzle, root, *input)
+		// report input errors here, testing.Benchmark only reports failure
+		if err := aoc.ParseFile(puzzle.New(), filename); err != nil {
+			return fmt.Errorf("%v: %w", puzzle, err)
+		}
+
+		for _, step := range aoctest.Steps(puzzle, filename) {
+			r := testing.Benchmark(step.Func)
+			if r.N == 0 {
+				return fmt.Errorf("%v %s failed", puzzle, step.Name)
+			}
+
+			key := fmt.Sprintf("%v %s", puzzle, step.Name)
+			result := benchResult{r.NsPerOp(), r.AllocsPerOp(), r.AllocedBytesPerOp()}
+			results[key] = result
+
+			comparison := ""
+			if old, ok := base[key]; ok && old.NsPerOp > 0 {
+				ratio := float64(result.NsPerOp) / float64(old.NsPerOp)
+				comparison = fmt.Sprintf("%+.0f%%", (ratio-1)*100)
+				if ratio > *threshold {
+					comparison += " REGRESSION"
+					regressions++
+				}
+			}
+
+			fmt.Fprintf(w, "%v\t%s\t%v\t%d\t%d\t%s\t\n",
+				puzzle, step.Name, time.Duration(result.NsPerOp), result.AllocsPerOp, result.BytesPerOp, comparison)
+		}
+	}
+	w.Flush()
+
+	if *save != "" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(*save, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+	}
+
+	if regressions > 0 {
+		return fmt.Errorf("%d steps regressed", regressions)
+	}
+	return nil
+}
@@ -0,0 +1,13 @@
+package main
+
+// Every day registers itself with the runner from its init function.
+import (
+	_ "github.com/thonda28/adventofcode/2024/01"
+	_ "github.com/thonda28/adventofcode/2024/02"
+	_ "github.com/thonda28/adventofcode/2024/03"
+	_ "github.com/thonda28/adventofcode/2024/04"
+	_ "github.com/thonda28/adventofcode/2024/05"
+	_ "github.com/thonda28/adventofcode/2024/06"
+	_ "github.com/thonda28/adventofcode/2024/07"
+	_ "github.com/thonda28/adventofcode/2024/08"
+)
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aocweb"
+)
+
+// addClientFlags registers the flags configuring the website client.
+// The returned function builds the client after the flags are parsed.
+func addClientFlags(fs *flag.FlagSet) func() (*aocweb.Client, error) {
+	baseURL := fs.String("base-url", envOr("AOC_BASE_URL", aocweb.DefaultBaseURL), "website URL ($AOC_BASE_URL)")
+	userAgent := fs.String("user-agent", envOr("AOC_USER_AGENT", aocweb.DefaultUserAgent), "User-Agent header ($AOC_USER_AGENT)")
+
+	return func() (*aocweb.Client, error) {
+		session, err := aocweb.LoadSession()
+		if err != nil {
+			return nil, err
+		}
+		client := aocweb.NewClient(session)
+		client.BaseURL = *baseURL
+		client.UserAgent = *userAgent
+		return client, nil
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+func fetchCommand(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ContinueOnError)
+	examples := fs.Bool("examples", false, "also extract the examples of the puzzle page into dayNN.example*")
+	page := fs.String("page", "", "read the puzzle page from this saved HTML file instead of downloading it (implies --examples)")
+	newClient := addClientFlags(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	year, day, err := parseYearDay(positional)
+	if err != nil {
+		return err
+	}
+
+	var puzzles []aoc.Puzzle
+	if day == 0 {
+		puzzles = aoc.Puzzles(year)
+	} else {
+		// the day does not need to be registered yet
+		puzzles = []aoc.Puzzle{{Year: year, Day: day}}
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	if *page != "" {
+		if len(puzzles) != 1 {
+			return errors.New("--page needs yyyy dd")
+		}
+		data, err := os.ReadFile(*page)
+		if err != nil {
+			return err
+		}
+		return writeExamples(puzzles[0], root, data)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	for _, puzzle := range puzzles {
+		filename := puzzle.InputFile(root, "input")
+		fetched, err := client.FetchInput(ctx, puzzle.Year, puzzle.Day, filename)
+		if err != nil {
+			return err
+		}
+		if fetched {
+			fmt.Printf("Downloaded: %s\n", filename)
+		} else {
+			fmt.Printf("File already exists: %s\n", filename)
+		}
+
+		if *examples {
+			data, err := client.Description(ctx, puzzle.Year, puzzle.Day)
+			if err != nil {
+				return err
+			}
+			if err := writeExamples(puzzle, root, data); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeExamples saves the examples of the puzzle page as dayNN.example,
+// dayNN.example2, ... and seeds dayNN.answers with the answers the page
+// gives for them. Existing example files and answers are kept.
+func writeExamples(puzzle aoc.Puzzle, root string, page []byte) error {
+	examples := aocweb.ExtractExamples(string(page))
+	if len(examples) == 0 {
+		fmt.Println("No examples found")
+		return nil
+	}
+
+	answersFile := puzzle.AnswersFile(root)
+	answers, err := aoc.ReadAnswers(answersFile)
+	if err != nil {
+		return err
+	}
+	answersChanged := false
+
+	for i, example := range examples {
+		name := aocweb.ExampleName(i)
+		filename := puzzle.InputFile(root, name)
+		if info, err := os.Stat(filename); err == nil && info.Size() > 0 {
+			fmt.Printf("File already exists: %s\n", filename)
+		} else {
+			if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filename, []byte(example.Input), 0o644); err != nil {
+				return err
+			}
+			fmt.Printf("Created file: %s\n", filename)
+		}
+
+		for part := 1; part <= 2; part++ {
+			answer, ok := example.Answers[part]
+			key := aoc.AnswerKey{Input: name, Part: part}
+			if _, recorded := answers[key]; !ok || recorded {
+				continue
+			}
+			answers[key] = answer
+			answersChanged = true
+			fmt.Printf("Expected answer of %s part %d: %d\n", name, part, answer)
+		}
+	}
+
+	if !answersChanged {
+		return nil
+	}
+	return aoc.WriteAnswers(answersFile, answers)
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func genCommand(args []string) error {
+	fs := flag.NewFlagSet("gen", flag.ContinueOnError)
+	var opts aoc.GenOptions
+	fs.IntVar(&opts.Size, "size", 0, "main dimension of the input, e.g. lines or grid side (0 for about a real input)")
+	fs.IntVar(&opts.Length, "length", 0, "length of the list on each line, where the puzzle has one (0 for the default)")
+	seed := fs.Uint64("seed", 1, "random seed; the same seed and options give the same input")
+	output := fs.String("o", "", "write to this file instead of stdout, gzip-compressed if it ends in .gz")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	year, day, err := parseYearDay(positional)
+	if err != nil {
+		return err
+	}
+	if day == 0 {
+		return errors.New("expected yyyy dd")
+	}
+	if opts.Size < 0 || opts.Length < 0 {
+		return errors.New("--size and --length must not be negative")
+	}
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		return fmt.Errorf("%d/%02d is not registered", year, day)
+	}
+	generate, ok := puzzle.Generator()
+	if !ok {
+		return fmt.Errorf("%v has no generator", puzzle)
+	}
+
+	var w io.Writer = os.Stdout
+	// closed in reverse order once everything is written
+	var closers []io.Closer
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return err
+		}
+		w = file
+		closers = append(closers, file)
+
+		if strings.HasSuffix(*output, ".gz") {
+			gz := gzip.NewWriter(file)
+			w = gz
+			closers = append(closers, gz)
+		}
+	}
+
+	buffered := bufio.NewWriterSize(w, 1<<20)
+	err = generate(buffered, aoc.NewRand(*seed), opts)
+	if err == nil {
+		err = buffered.Flush()
+	}
+	for i := len(closers) - 1; i >= 0; i-- {
+		if closeErr := closers[i].Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
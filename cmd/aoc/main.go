@@ -0,0 +1,107 @@
+// Command aoc runs the Advent of Code solutions of this repository.
+//
+// Usage:
+//
+//	aoc run yyyy [dd] [--part 1|2] [--input example|input|path|-] [--no-validate] [--json] [< input]
+//	aoc new yyyy dd [--kind default|grid|ints|sections]
+//	aoc verify [yyyy [dd]] [-v]
+//	aoc bench [yyyy [dd]] [--input name] [--save file] [--baseline file] [--threshold ratio]
+//	aoc fetch yyyy [dd] [--examples] [--page file] [--base-url url] [--user-agent ua]
+//	aoc submit yyyy dd --part 1|2 [--base-url url] [--user-agent ua]
+//	aoc gen yyyy dd [--size n] [--length n] [--seed n] [-o file[.gz]]
+//
+// The website session token is read from $AOC_SESSION or from aoc/session
+// in the user config directory.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"run", "yyyy [dd] [--part 1|2] [--input example|input|path|-] [--no-validate] [--json]", runCommand},
+	{"new", "yyyy dd [--kind default|grid|ints|sections]", newCommand},
+	{"verify", "[yyyy [dd]] [-v]", verifyCommand},
+	{"bench", "[yyyy [dd]] [--input name] [--save file] [--baseline file] [--threshold ratio]", benchCommand},
+	{"fetch", "yyyy [dd] [--examples] [--page file] [--base-url url] [--user-agent ua]", fetchCommand},
+	{"submit", "yyyy dd --part 1|2 [--base-url url] [--user-agent ua]", submitCommand},
+	{"gen", "yyyy dd [--size n] [--length n] [--seed n] [-o file[.gz]]", genCommand},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  aoc %s %s\n", cmd.name, cmd.usage)
+	}
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != os.Args[1] {
+			continue
+		}
+		if err := cmd.run(os.Args[2:]); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				os.Exit(2)
+			}
+			fmt.Fprintf(os.Stderr, "aoc %s: %v\n", cmd.name, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintf(os.Stderr, "aoc: unknown command %q\n", os.Args[1])
+	usage()
+	os.Exit(2)
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments, and returns the positional arguments.
+func parseArgs(fs *flag.FlagSet, args []string) (positional []string, err error) {
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positional, nil
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// parseYearDay parses the "yyyy [dd]" arguments. day is 0 if omitted.
+func parseYearDay(args []string) (year, day int, err error) {
+	if len(args) < 1 || len(args) > 2 {
+		return 0, 0, errors.New("expected yyyy [dd]")
+	}
+
+	year, err = strconv.Atoi(args[0])
+	if err != nil || year < 2015 || year > 9999 {
+		return 0, 0, fmt.Errorf("invalid year %q", args[0])
+	}
+	if len(args) == 1 {
+		return year, 0, nil
+	}
+
+	day, err = strconv.Atoi(args[1])
+	if err != nil || day < 1 || day > 25 {
+		return 0, 0, fmt.Errorf("invalid day %q", args[1])
+	}
+	return year, day, nil
+}
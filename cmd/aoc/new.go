@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+const modulePath = "github.com/thonda28/adventofcode"
+
+// templateData is passed to the templates in template/<kind>.go.tmpl and
+// to template/_test.go.tmpl.
+type templateData struct {
+	Year    int
+	Day     int
+	Package string // e.g. day09
+}
+
+func newCommand(args []string) error {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	kind := fs.String("kind", "default", "template to use: template/<kind>.go.tmpl")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	year, day, err := parseYearDay(positional)
+	if err != nil {
+		return err
+	}
+	if day == 0 {
+		return errors.New("expected yyyy dd")
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	tmpl, err := loadTemplate(root, *kind)
+	if err != nil {
+		return err
+	}
+
+	dd := fmt.Sprintf("%02d", day)
+	targetDir := filepath.Join(root, strconv.Itoa(year), dd)
+	if err := os.MkdirAll(targetDir, 0o755); err != nil {
+		return err
+	}
+
+	testTmpl, err := template.ParseFiles(filepath.Join(root, "template", "_test.go.tmpl"))
+	if err != nil {
+		return err
+	}
+
+	data := templateData{year, day, "day" + dd}
+	if err := renderTemplate(tmpl, data, filepath.Join(targetDir, "day"+dd+".go")); err != nil {
+		return err
+	}
+	if err := renderTemplate(testTmpl, data, filepath.Join(targetDir, "day"+dd+"_test.go")); err != nil {
+		return err
+	}
+
+	for _, ext := range []string{"example", "input"} {
+		if err := createEmpty(filepath.Join(targetDir, "day"+dd+"."+ext)); err != nil {
+			return err
+		}
+	}
+
+	importPath := fmt.Sprintf("%s/%d/%s", modulePath, year, dd)
+	return registerDay(filepath.Join(root, "cmd", "aoc", "days.go"), importPath)
+}
+
+func loadTemplate(root, kind string) (*template.Template, error) {
+	filename := filepath.Join(root, "template", kind+".go.tmpl")
+	if _, err := os.Stat(filename); err != nil || strings.HasPrefix(kind, "_") {
+		var kinds []string
+		files, _ := filepath.Glob(filepath.Join(root, "template", "[^_]*.go.tmpl"))
+		for _, f := range files {
+			kinds = append(kinds, strings.TrimSuffix(filepath.Base(f), ".go.tmpl"))
+		}
+		return nil, fmt.Errorf("unknown template kind %q (available: %s)", kind, strings.Join(kinds, ", "))
+	}
+	return template.ParseFiles(filename)
+}
+
+func renderTemplate(tmpl *template.Template, data templateData, dest string) error {
+	if _, err := os.Stat(dest); err == nil {
+		fmt.Printf("File already exists: %s\n", dest)
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("%s: rendered code is invalid: %w", tmpl.Name(), err)
+	}
+	if err := os.WriteFile(dest, src, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Created file: %s\n", dest)
+	return nil
+}
+
+func createEmpty(filename string) error {
+	if _, err := os.Stat(filename); err == nil {
+		fmt.Printf("File already exists: %s\n", filename)
+		return nil
+	}
+	if err := os.WriteFile(filename, nil, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Created file: %s\n", filename)
+	return nil
+}
+
+// registerDay adds a blank import of the day's package to days.go so that
+// the runner picks it up.
+func registerDay(daysFile, importPath string) error {
+	src, err := os.ReadFile(daysFile)
+	if err != nil {
+		return err
+	}
+
+	importLine := fmt.Sprintf("\t_ %q\n", importPath)
+	if bytes.Contains(src, []byte(importLine)) {
+		fmt.Printf("Already registered: %s\n", importPath)
+		return nil
+	}
+
+	end := bytes.LastIndex(src, []byte("\n)"))
+	if end == -1 {
+		return fmt.Errorf("%s: import block not found", daysFile)
+	}
+	edited := make([]byte, 0, len(src)+len(importLine))
+	edited = append(edited, src[:end+1]...)
+	edited = append(edited, importLine...)
+	edited = append(edited, src[end+1:]...)
+
+	// format.Source sorts the imports, keeping the days in order.
+	formatted, err := format.Source(edited)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(daysFile, formatted, 0o644); err != nil {
+		return err
+	}
+	fmt.Printf("Registered: %s\n", importPath)
+	return nil
+}
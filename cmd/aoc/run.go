@@ -0,0 +1,164 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	part := fs.Int("part", 0, "part to run (1 or 2, 0 for both)")
+	inputName := fs.String("input", "input", `input to solve: "example", "input", a file path or "-" for stdin (read by default only when redirected from a file); validated inputs are held in memory, use --no-validate to stream them`)
+	noValidate := fs.Bool("no-validate", false, "skip the input invariants and stream the input; validating holds the whole input in memory")
+	asJSON := fs.Bool("json", false, "print one JSON object per answer, with the details of solvers that report them")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return errors.New("expected yyyy [dd]")
+	}
+	if *part < 0 || *part > 2 {
+		return fmt.Errorf("invalid part %d", *part)
+	}
+
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	// "aoc run 2024 3 < file" reads the redirected stdin; a pipe, often an
+	// idle or empty one in scripts, takes an explicit --input -
+	if !flagSet(fs, "input") && len(puzzles) == 1 && stdinFromFile() {
+		*inputName = input.Stdin
+	}
+	if *inputName == input.Stdin && len(puzzles) != 1 {
+		return errors.New("stdin can only be read for a single day")
+	}
+
+	// a failing day is reported and the remaining days still run
+	failed := 0
+	for _, puzzle := range puzzles {
+		solver := puzzle.New()
+		if err := parse(solver, inputFile(puzzle, root, *inputName), !*noValidate); err != nil {
+			report(puzzle.String(), err)
+			failed++
+			continue
+		}
+		for p := 1; p <= 2; p++ {
+			if *part != 0 && *part != p {
+				continue
+			}
+			answer, err := aoc.SolvePart(solver, p)
+			if *asJSON {
+				// a failed part is printed too, its report may tell why
+				if err := printJSON(puzzle, p, answer, err, solver); err != nil {
+					report(fmt.Sprintf("%v part %d", puzzle, p), err)
+					failed++
+				}
+				continue
+			}
+			if err != nil {
+				report(fmt.Sprintf("%v part %d", puzzle, p), err)
+				failed++
+				continue
+			}
+			fmt.Printf("%v part %d: %d\n", puzzle, p, answer)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d failed", failed)
+	}
+	return nil
+}
+
+func parse(solver aoc.Solver, filename string, validate bool) error {
+	if validate {
+		return aoc.ParseFile(solver, filename)
+	}
+	r, err := input.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return solver.Parse(r)
+}
+
+// result is an answer printed by run --json.
+type result struct {
+	Puzzle string `json:"puzzle"`
+	Part   int    `json:"part"`
+	Answer *int   `json:"answer,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Report any    `json:"report,omitempty"`
+}
+
+// printJSON prints the answer or error of the part as a result. It returns
+// solveErr, or the error of the report.
+func printJSON(puzzle aoc.Puzzle, part, answer int, solveErr error, solver aoc.Solver) error {
+	res := result{Puzzle: puzzle.String(), Part: part}
+	if solveErr != nil {
+		res.Error = solveErr.Error()
+	} else {
+		res.Answer = &answer
+	}
+	if r, ok := solver.(aoc.Reporter); ok {
+		var err error
+		if res.Report, err = r.Report(part); err != nil {
+			return errors.Join(solveErr, err)
+		}
+	}
+	data, err := json.Marshal(res)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%s\n", data)
+	return solveErr
+}
+
+func flagSet(fs *flag.FlagSet, name string) (set bool) {
+	fs.Visit(func(f *flag.Flag) {
+		set = set || f.Name == name
+	})
+	return set
+}
+
+// stdinFromFile reports whether stdin is redirected from a non-empty
+// regular file.
+func stdinFromFile() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode().IsRegular() && info.Size() > 0
+}
+
+// report prints the error to stderr, one line per joined error such as the
+// violations found by validate.Check.
+func report(prefix string, err error) {
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", prefix, line)
+	}
+}
+
+// inputFile resolves the --input flag. A bare name such as "example" selects
+// the puzzle's dayNN.example; anything that looks like a path is used as is.
+func inputFile(puzzle aoc.Puzzle, root, name string) string {
+	if name == input.Stdin || strings.ContainsAny(name, `./\`) {
+		return name
+	}
+	return puzzle.InputFile(root, name)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+// runWithStdin runs the run command with stdin, a pipe fed with data, and
+// returns what it printed.
+func runWithStdin(t *testing.T, data string, args ...string) (string, error) {
+	t.Helper()
+
+	stdinR, stdinW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdoutR, stdoutW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin, stdout := os.Stdin, os.Stdout
+	os.Stdin, os.Stdout = stdinR, stdoutW
+	t.Cleanup(func() {
+		os.Stdin, os.Stdout = stdin, stdout
+		stdinR.Close()
+	})
+
+	go func() {
+		io.WriteString(stdinW, data)
+		stdinW.Close()
+	}()
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(stdoutR)
+		output <- string(data)
+	}()
+
+	err = runCommand(args)
+	stdoutW.Close()
+	return <-output, err
+}
+
+func TestRunIgnoresPipedStdin(t *testing.T) {
+	output, err := runWithStdin(t, "", "2024", "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2024/01 part 1: 1151792\n"; !strings.HasPrefix(output, want) {
+		t.Errorf("output = %q, want the answers of day01.input", output)
+	}
+}
+
+func TestRunEmptyStdin(t *testing.T) {
+	if _, err := runWithStdin(t, "", "2024", "1", "--input", "-"); err == nil {
+		t.Error("solved an empty stdin")
+	}
+}
+
+func TestRunStdin(t *testing.T) {
+	output, err := runWithStdin(t, "3   4\n4   3\n2   5\n1   3\n3   9\n3   3\n", "2024", "1", "--input", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "2024/01 part 1: 11\n2024/01 part 2: 31\n"; output != want {
+		t.Errorf("output = %q, want %q", output, want)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"time"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/aocweb"
+)
+
+func submitCommand(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ContinueOnError)
+	part := fs.Int("part", 0, "part to submit (1 or 2)")
+	newClient := addClientFlags(fs)
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	year, day, err := parseYearDay(positional)
+	if err != nil {
+		return err
+	}
+	if day == 0 {
+		return errors.New("expected yyyy dd")
+	}
+	if *part != 1 && *part != 2 {
+		return errors.New("--part must be 1 or 2")
+	}
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		return fmt.Errorf("%d/%02d is not registered", year, day)
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	answer, err := puzzle.Solve(puzzle.InputFile(root, "input"), *part)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%v part %d: %d\n", puzzle, *part, answer)
+
+	historyFile := puzzle.InputFile(root, "submissions")
+	history, err := aocweb.ReadHistory(historyFile)
+	if err != nil {
+		return err
+	}
+	if err := aocweb.CheckAttempt(history, *part, answer); err != nil {
+		return fmt.Errorf("not submitted: %w", err)
+	}
+
+	client, err := newClient()
+	if err != nil {
+		return err
+	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := client.Submit(ctx, year, day, *part, strconv.Itoa(answer))
+	if err != nil {
+		return err
+	}
+	attempt := aocweb.Attempt{Time: time.Now(), Part: *part, Answer: answer, Verdict: result.Verdict}
+	if err := aocweb.AppendHistory(historyFile, attempt); err != nil {
+		return err
+	}
+
+	fmt.Println(result.Message)
+	switch {
+	case result.Verdict == aocweb.Correct:
+		return recordAnswer(puzzle.AnswersFile(root), *part, answer)
+	case result.Verdict == aocweb.Unknown:
+		return errors.New("unrecognized response")
+	case result.Wait > 0:
+		fmt.Printf("Wait %v before the next submission.\n", result.Wait)
+	}
+	return nil
+}
+
+// recordAnswer adds an accepted answer for the real input to the answers
+// file used by aoc verify.
+func recordAnswer(answersFile string, part, answer int) error {
+	answers, err := aoc.ReadAnswers(answersFile)
+	if err != nil {
+		return err
+	}
+	answers[aoc.AnswerKey{Input: "input", Part: part}] = answer
+	if err := aoc.WriteAnswers(answersFile, answers); err != nil {
+		return err
+	}
+	fmt.Printf("Recorded in %s\n", answersFile)
+	return nil
+}
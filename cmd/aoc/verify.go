@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+func verifyCommand(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	verbose := fs.Bool("v", false, "also print the answers that match")
+
+	positional, err := parseArgs(fs, args)
+	if err != nil {
+		return err
+	}
+	puzzles, err := selectPuzzles(positional)
+	if err != nil {
+		return err
+	}
+
+	root, err := aoc.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	var passed, failed int
+	var unverified []aoc.Puzzle
+	for _, puzzle := range puzzles {
+		results, err := aoc.Verify(root, puzzle)
+		if err != nil {
+			return err
+		}
+		if len(results) == 0 {
+			unverified = append(unverified, puzzle)
+		}
+		for _, result := range results {
+			if result.OK() {
+				passed++
+			} else {
+				failed++
+			}
+			if *verbose || !result.OK() {
+				fmt.Println(result)
+			}
+		}
+	}
+
+	fmt.Printf("%d ok, %d failed\n", passed, failed)
+	for _, puzzle := range unverified {
+		fmt.Printf("%v has no recorded answers\n", puzzle)
+	}
+	if failed > 0 {
+		return errors.New("answers do not match")
+	}
+	return nil
+}
+
+// selectPuzzles returns the registered puzzles matching "[yyyy [dd]]".
+func selectPuzzles(args []string) ([]aoc.Puzzle, error) {
+	if len(args) == 0 {
+		return aoc.Puzzles(0), nil
+	}
+
+	year, day, err := parseYearDay(args)
+	if err != nil {
+		return nil, err
+	}
+	if day == 0 {
+		puzzles := aoc.Puzzles(year)
+		if len(puzzles) == 0 {
+			return nil, fmt.Errorf("no puzzle registered for %d", year)
+		}
+		return puzzles, nil
+	}
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		return nil, fmt.Errorf("%d/%02d is not registered", year, day)
+	}
+	return []aoc.Puzzle{puzzle}, nil
+}
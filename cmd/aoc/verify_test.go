@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+// TestVerify checks every registered solver against its recorded answers.
+func TestVerify(t *testing.T) {
+	root, err := aoc.FindRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, puzzle := range aoc.Puzzles(0) {
+		t.Run(puzzle.String(), func(t *testing.T) {
+			results, err := aoc.Verify(root, puzzle)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) == 0 {
+				t.Skip("no recorded answers")
+			}
+			for _, result := range results {
+				if !result.OK() {
+					t.Error(result)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/thonda28/adventofcode
+
+go 1.23.2
@@ -0,0 +1,146 @@
+package aoc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AnswerKey identifies an answer by input name ("example", "input", ...)
+// and part.
+type AnswerKey struct {
+	Input string
+	Part  int
+}
+
+// Answers are the known correct answers of a puzzle. They are stored next
+// to the inputs in dayNN.answers, one "<input> <part> <answer>" per line.
+type Answers map[AnswerKey]int
+
+// AnswersFile returns the path of the puzzle's answers file.
+func (p Puzzle) AnswersFile(root string) string {
+	return p.InputFile(root, "answers")
+}
+
+// ReadAnswers reads an answers file. A missing file means no answers.
+func ReadAnswers(filename string) (Answers, error) {
+	answers := make(Answers)
+
+	file, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return answers, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("%s:%d: expected \"<input> <part> <answer>\"", filename, lineNum)
+		}
+		part, err := strconv.Atoi(fields[1])
+		if err != nil || (part != 1 && part != 2) {
+			return nil, fmt.Errorf("%s:%d: invalid part %q", filename, lineNum, fields[1])
+		}
+		answer, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, lineNum, err)
+		}
+		answers[AnswerKey{fields[0], part}] = answer
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return answers, nil
+}
+
+// WriteAnswers writes the answers sorted by input name and part.
+func WriteAnswers(filename string, answers Answers) error {
+	var b strings.Builder
+	b.WriteString("# input part answer\n")
+	for _, key := range answers.Keys() {
+		fmt.Fprintf(&b, "%s %d %d\n", key.Input, key.Part, answers[key])
+	}
+	return os.WriteFile(filename, []byte(b.String()), 0o644)
+}
+
+// Keys returns the keys sorted by input name and part.
+func (a Answers) Keys() []AnswerKey {
+	keys := make([]AnswerKey, 0, len(a))
+	for key := range a {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Input != keys[j].Input {
+			return keys[i].Input < keys[j].Input
+		}
+		return keys[i].Part < keys[j].Part
+	})
+	return keys
+}
+
+// Result is the outcome of checking one recorded answer.
+type Result struct {
+	Puzzle Puzzle
+	AnswerKey
+	Want, Got int
+	Err       error
+}
+
+func (r Result) OK() bool {
+	return r.Err == nil && r.Got == r.Want
+}
+
+func (r Result) String() string {
+	prefix := fmt.Sprintf("%v %s part %d", r.Puzzle, r.Input, r.Part)
+	switch {
+	case r.Err != nil:
+		return fmt.Sprintf("%s: FAIL want %d, error: %v", prefix, r.Want, r.Err)
+	case r.Got != r.Want:
+		return fmt.Sprintf("%s: FAIL want %d, got %d", prefix, r.Want, r.Got)
+	default:
+		return fmt.Sprintf("%s: ok %d", prefix, r.Got)
+	}
+}
+
+// Verify solves every input that has a recorded answer and compares the
+// results. Each input is parsed once for both parts.
+func Verify(root string, puzzle Puzzle) ([]Result, error) {
+	answers, err := ReadAnswers(puzzle.AnswersFile(root))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []Result
+	var solver Solver
+	var parseErr error
+	for i, key := range answers.Keys() {
+		if i == 0 || key.Input != results[i-1].Input {
+			solver = puzzle.New()
+			parseErr = ParseFile(solver, puzzle.InputFile(root, key.Input))
+		}
+
+		result := Result{Puzzle: puzzle, AnswerKey: key, Want: answers[key]}
+		if parseErr != nil {
+			result.Err = parseErr
+		} else {
+			result.Got, result.Err = SolvePart(solver, key.Part)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
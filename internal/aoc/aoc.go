@@ -0,0 +1,162 @@
+// Package aoc defines the Solver interface and the registry of solvers
+// keyed by year and day, used by cmd/aoc and by tests.
+package aoc
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/thonda28/adventofcode/internal/input"
+	"github.com/thonda28/adventofcode/internal/validate"
+)
+
+// Solver solves a single puzzle. Parse is called once with the input, then
+// Part1 and Part2 may be called in any order on the parsed data. Errors
+// about the input are reported with input.Name(r) as the file name.
+type Solver interface {
+	Parse(r io.Reader) error
+	Part1() (int, error)
+	Part2() (int, error)
+}
+
+// Reporter is implemented by solvers that can detail an answer beyond the
+// number, e.g. the positions it counts, as a value encodable as JSON.
+type Reporter interface {
+	Report(part int) (any, error)
+}
+
+type Puzzle struct {
+	Year, Day int
+
+	// New returns a fresh solver that has not parsed any input yet.
+	New func() Solver
+}
+
+var puzzles = make(map[[2]int]Puzzle)
+
+// Register makes a puzzle available to the runner.
+// Each day calls it from its init function.
+func Register(year, day int, newSolver func() Solver) {
+	key := [2]int{year, day}
+	if _, ok := puzzles[key]; ok {
+		panic(fmt.Sprintf("aoc: %d/%02d registered twice", year, day))
+	}
+	puzzles[key] = Puzzle{year, day, newSolver}
+}
+
+func Lookup(year, day int) (Puzzle, bool) {
+	puzzle, ok := puzzles[[2]int{year, day}]
+	return puzzle, ok
+}
+
+// Puzzles returns the registered puzzles of the year (all years if 0)
+// ordered by year and day.
+func Puzzles(year int) (result []Puzzle) {
+	for _, puzzle := range puzzles {
+		if year == 0 || puzzle.Year == year {
+			result = append(result, puzzle)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Year != result[j].Year {
+			return result[i].Year < result[j].Year
+		}
+		return result[i].Day < result[j].Day
+	})
+	return result
+}
+
+func (p Puzzle) String() string {
+	return fmt.Sprintf("%d/%02d", p.Year, p.Day)
+}
+
+// Parse validates the input against the invariants of the solver, if it
+// declares any, and parses it. Validation reads the whole input into
+// memory first; call solver.Parse directly to stream it.
+func Parse(solver Solver, r io.Reader) error {
+	v, ok := solver.(validate.Validator)
+	if !ok {
+		return solver.Parse(r)
+	}
+
+	name := input.Name(r)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	lines, err := input.Lines(input.Named(name, bytes.NewReader(data)))
+	if err != nil {
+		return err
+	}
+	if err := validate.Check(lines, v.Invariants()); err != nil {
+		return err
+	}
+	return solver.Parse(input.Named(name, bytes.NewReader(data)))
+}
+
+// ParseFile opens the input file with input.Open and parses it like Parse.
+func ParseFile(solver Solver, filename string) error {
+	r, err := input.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer r.Close()
+	return Parse(solver, r)
+}
+
+// Solve parses the input file and solves the given part (1 or 2).
+func (p Puzzle) Solve(filename string, part int) (int, error) {
+	solver := p.New()
+	if err := ParseFile(solver, filename); err != nil {
+		return 0, err
+	}
+	return SolvePart(solver, part)
+}
+
+// SolvePart solves the given part (1 or 2) with an already parsed solver.
+func SolvePart(solver Solver, part int) (int, error) {
+	switch part {
+	case 1:
+		return solver.Part1()
+	case 2:
+		return solver.Part2()
+	default:
+		return 0, fmt.Errorf("invalid part %d", part)
+	}
+}
+
+// InputFile returns the path of the input file of the puzzle, e.g.
+// 2024/06/day06.example for name "example".
+func (p Puzzle) InputFile(root, name string) string {
+	return filepath.Join(
+		root,
+		strconv.Itoa(p.Year),
+		fmt.Sprintf("%02d", p.Day),
+		fmt.Sprintf("day%02d.%s", p.Day, name),
+	)
+}
+
+// FindRoot returns the repository root, the nearest directory containing
+// go.mod from the working directory upwards.
+func FindRoot() (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("repository root (go.mod) not found")
+		}
+		dir = parent
+	}
+}
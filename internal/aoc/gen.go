@@ -0,0 +1,57 @@
+package aoc
+
+import (
+	"fmt"
+	"io"
+	"math/rand/v2"
+)
+
+// Generator writes a random input of a puzzle that satisfies its format and
+// invariants, for stress tests. The same options and seed of rng give the
+// same input.
+type Generator func(w io.Writer, rng *rand.Rand, opts GenOptions) error
+
+// GenOptions configures a Generator. Zero values select defaults about the
+// size of a real input.
+type GenOptions struct {
+	// Size is the main dimension of the input, e.g. the number of lines or
+	// the side of a grid.
+	Size int
+	// Length is the length of the list on each line, e.g. the terms of an
+	// equation, for puzzles that have one.
+	Length int
+}
+
+// Or returns the options with the zero fields set from defaults.
+func (o GenOptions) Or(defaults GenOptions) GenOptions {
+	if o.Size == 0 {
+		o.Size = defaults.Size
+	}
+	if o.Length == 0 {
+		o.Length = defaults.Length
+	}
+	return o
+}
+
+var generators = make(map[[2]int]Generator)
+
+// RegisterGenerator makes an input generator of a puzzle available to
+// "aoc gen". Each day calls it from its init function.
+func RegisterGenerator(year, day int, generate Generator) {
+	key := [2]int{year, day}
+	if _, ok := generators[key]; ok {
+		panic(fmt.Sprintf("aoc: generator of %d/%02d registered twice", year, day))
+	}
+	generators[key] = generate
+}
+
+// Generator returns the input generator of the puzzle, if it has one.
+func (p Puzzle) Generator() (Generator, bool) {
+	generate, ok := generators[[2]int{p.Year, p.Day}]
+	return generate, ok
+}
+
+// NewRand returns the random source generators are run with.
+func NewRand(seed uint64) *rand.Rand {
+	return rand.New(rand.NewPCG(seed, seed))
+}
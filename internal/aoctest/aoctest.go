@@ -0,0 +1,73 @@
+// Package aoctest runs a day's examples from its _test.go file.
+package aoctest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+// Examples solves every dayNN.example* file of the puzzle and compares the
+// results with the answers recorded for it in dayNN.answers. Only the parts
+// with a recorded answer are run, so an example that belongs to a single
+// part declares only that part.
+func Examples(t *testing.T, year, day int) {
+	t.Helper()
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		t.Fatalf("%d/%02d is not registered", year, day)
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	answers, err := aoc.ReadAnswers(puzzle.AnswersFile(root))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	examples, err := filepath.Glob(puzzle.InputFile(root, "example*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(examples) == 0 {
+		t.Fatal("no example files")
+	}
+
+	for _, filename := range examples {
+		name := strings.TrimPrefix(filepath.Ext(filename), ".")
+		t.Run(name, func(t *testing.T) {
+			if info, err := os.Stat(filename); err == nil && info.Size() == 0 {
+				t.Skip("empty example")
+			}
+
+			solver := puzzle.New()
+			if err := aoc.ParseFile(solver, filename); err != nil {
+				t.Fatal(err)
+			}
+
+			tested := false
+			for part := 1; part <= 2; part++ {
+				want, ok := answers[aoc.AnswerKey{Input: name, Part: part}]
+				if !ok {
+					continue
+				}
+				tested = true
+
+				got, err := aoc.SolvePart(solver, part)
+				if err != nil {
+					t.Errorf("part %d: %v", part, err)
+				} else if got != want {
+					t.Errorf("part %d = %d, want %d", part, got, want)
+				}
+			}
+			if !tested {
+				t.Errorf("no answers recorded for %s in %s", name, filepath.Base(puzzle.AnswersFile(root)))
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package aoctest
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+// Step is a benchmark of one step of solving a puzzle.
+type Step struct {
+	Name string // Parse, Part1 or Part2
+	Func func(b *testing.B)
+}
+
+// Steps returns the benchmarks of parsing the input file and of solving
+// each part. The file is read into memory beforehand, so Parse is timed
+// without I/O, and the parts are timed on input parsed beforehand.
+func Steps(puzzle aoc.Puzzle, filename string) []Step {
+	data, readErr := input.ReadFile(filename)
+	newReader := func() io.Reader {
+		return input.Named(filename, bytes.NewReader(data))
+	}
+
+	parse := func(b *testing.B) {
+		if readErr != nil {
+			b.Fatal(readErr)
+		}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := puzzle.New().Parse(newReader()); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+
+	solve := func(part int) func(b *testing.B) {
+		return func(b *testing.B) {
+			if readErr != nil {
+				b.Fatal(readErr)
+			}
+			solver := puzzle.New()
+			if err := solver.Parse(newReader()); err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := aoc.SolvePart(solver, part); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+
+	return []Step{
+		{"Parse", parse},
+		{"Part1", solve(1)},
+		{"Part2", solve(2)},
+	}
+}
+
+// Benchmark runs the steps on the puzzle's real input as sub-benchmarks.
+// It is called from the day's _test.go file.
+func Benchmark(b *testing.B, year, day int) {
+	b.Helper()
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		b.Fatalf("%d/%02d is not registered", year, day)
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	filename := puzzle.InputFile(root, "input")
+	if info, err := os.Stat(filename); err != nil || info.Size() == 0 {
+		b.Skip("no input")
+	}
+
+	for _, step := range Steps(puzzle, filename) {
+		b.Run(step.Name, step.Func)
+	}
+}
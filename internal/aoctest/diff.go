@@ -0,0 +1,118 @@
+package aoctest
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+// DiffOptions configures Differential.
+type DiffOptions struct {
+	// Inputs is the number of generated inputs, with seeds 1 to Inputs.
+	Inputs int
+	// Gen configures the generator. Small inputs keep the reference fast
+	// and the reported input readable.
+	Gen aoc.GenOptions
+	// Simplify maps bytes to simpler ones tried while minimizing a
+	// disagreeing input, e.g. an obstruction to an empty cell.
+	Simplify map[byte]byte
+}
+
+// Differential solves inputs from the puzzle's generator with both the
+// puzzle's solver and a naive reference solver, and fails on the first
+// input where their answers disagree. The input is reported minimized:
+// lines are removed and bytes simplified as long as the solvers still
+// disagree.
+func Differential(t *testing.T, year, day int, reference func() aoc.Solver, opts DiffOptions) {
+	t.Helper()
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		t.Fatalf("%d/%02d is not registered", year, day)
+	}
+	generate, ok := puzzle.Generator()
+	if !ok {
+		t.Fatalf("%v has no generator", puzzle)
+	}
+
+	disagree := func(data []byte) bool {
+		_, found := compare(puzzle, reference, data)
+		return found
+	}
+	for seed := 1; seed <= opts.Inputs; seed++ {
+		var buf bytes.Buffer
+		if err := generate(&buf, aoc.NewRand(uint64(seed)), opts.Gen); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		if !disagree(buf.Bytes()) {
+			continue
+		}
+
+		minimized := minimize(buf.Bytes(), disagree, opts.Simplify)
+		diff, _ := compare(puzzle, reference, minimized)
+		t.Fatalf("seed %d: %s\nminimized input:\n%s", seed, diff, minimized)
+	}
+}
+
+// compare solves the input with both solvers and describes how their
+// answers differ. Inputs that either solver rejects, including inputs
+// violating the puzzle's invariants, do not count.
+func compare(puzzle aoc.Puzzle, reference func() aoc.Solver, data []byte) (diff string, found bool) {
+	solver, ref := puzzle.New(), reference()
+	if err := aoc.Parse(solver, input.Named("generated", bytes.NewReader(data))); err != nil {
+		return "", false
+	}
+	if err := ref.Parse(input.Named("generated", bytes.NewReader(data))); err != nil {
+		return "", false
+	}
+
+	for part := 1; part <= 2; part++ {
+		got, err := aoc.SolvePart(solver, part)
+		want, refErr := aoc.SolvePart(ref, part)
+		switch {
+		case err != nil && refErr != nil:
+		case err != nil || refErr != nil:
+			return fmt.Sprintf("part %d: solver error %v, reference error %v", part, err, refErr), true
+		case got != want:
+			return fmt.Sprintf("part %d = %d, reference %d", part, got, want), true
+		}
+	}
+	return "", false
+}
+
+// minimize shrinks data while keep holds, first by removing ever smaller
+// chunks of lines, then by simplifying single bytes.
+func minimize(data []byte, keep func([]byte) bool, simplify map[byte]byte) []byte {
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	join := func(lines [][]byte) []byte {
+		return bytes.Join(lines, nil)
+	}
+
+	for chunk := len(lines) / 2; chunk >= 1; chunk /= 2 {
+		for start := 0; start+chunk <= len(lines); {
+			candidate := append(append([][]byte{}, lines[:start]...), lines[start+chunk:]...)
+			if len(candidate) > 0 && keep(join(candidate)) {
+				lines = candidate
+			} else {
+				start += chunk
+			}
+		}
+	}
+
+	data = join(lines)
+	for i := range data {
+		simpler, ok := simplify[data[i]]
+		if !ok {
+			continue
+		}
+		original := data[i]
+		data[i] = simpler
+		if !keep(data) {
+			data[i] = original
+		}
+	}
+	return data
+}
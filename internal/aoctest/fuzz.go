@@ -0,0 +1,45 @@
+package aoctest
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+// FuzzParse checks that the puzzle's solver never panics while validating
+// and parsing any input. The dayNN.example* files seed the corpus. It is
+// called from the day's _test.go file.
+func FuzzParse(f *testing.F, year, day int) {
+	f.Helper()
+
+	puzzle, ok := aoc.Lookup(year, day)
+	if !ok {
+		f.Fatalf("%d/%02d is not registered", year, day)
+	}
+	root, err := aoc.FindRoot()
+	if err != nil {
+		f.Fatal(err)
+	}
+
+	examples, err := filepath.Glob(puzzle.InputFile(root, "example*"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	for _, filename := range examples {
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			f.Fatal(err)
+		}
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// errors are expected, panics are not
+		_ = aoc.Parse(puzzle.New(), input.Named("fuzz", bytes.NewReader(data)))
+		_ = puzzle.New().Parse(input.Named("fuzz", bytes.NewReader(data)))
+	})
+}
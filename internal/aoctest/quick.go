@@ -0,0 +1,34 @@
+package aoctest
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/thonda28/adventofcode/internal/aoc"
+)
+
+// Quick checks the property f with testing/quick. The random values come
+// from a fixed seed, so that a failure can be reproduced.
+func Quick(t *testing.T, f any) {
+	t.Helper()
+
+	config := &quick.Config{MaxCount: 200, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(f, config); err != nil {
+		t.Error(err)
+	}
+}
+
+// Generated returns the input the puzzle's generator writes for the seed
+// and options, for properties over realistic inputs.
+func Generated(t testing.TB, generate aoc.Generator, seed uint64, opts aoc.GenOptions) io.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if err := generate(&buf, aoc.NewRand(seed), opts); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
@@ -0,0 +1,168 @@
+// Package aocweb talks to the Advent of Code website.
+//
+// The base URL is configurable so that the client can be run against a
+// local stand-in in tests.
+package aocweb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL   = "https://adventofcode.com"
+	DefaultUserAgent = "github.com/thonda28/adventofcode/cmd/aoc"
+
+	// DefaultMinInterval is the least time between two requests.
+	DefaultMinInterval = 3 * time.Second
+)
+
+// Client sends authenticated requests, at most one per MinInterval.
+type Client struct {
+	BaseURL     string
+	Session     string // value of the "session" cookie
+	UserAgent   string
+	MinInterval time.Duration
+	HTTPClient  *http.Client
+
+	mu          sync.Mutex
+	lastRequest time.Time
+}
+
+func NewClient(session string) *Client {
+	return &Client{
+		BaseURL:     DefaultBaseURL,
+		Session:     session,
+		UserAgent:   DefaultUserAgent,
+		MinInterval: DefaultMinInterval,
+		HTTPClient:  http.DefaultClient,
+	}
+}
+
+// LoadSession returns the session token from $AOC_SESSION, or else from
+// the file aoc/session in the user config directory.
+func LoadSession() (string, error) {
+	if session := strings.TrimSpace(os.Getenv("AOC_SESSION")); session != "" {
+		return session, nil
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	filename := filepath.Join(dir, "aoc", "session")
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", fmt.Errorf("no session token: set AOC_SESSION or write it to %s", filename)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// StatusError is returned for a response other than 200 OK.
+type StatusError struct {
+	Method     string
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	msg := fmt.Sprintf("%s %s: %s", e.Method, e.URL, http.StatusText(e.StatusCode))
+	switch e.StatusCode {
+	case http.StatusNotFound:
+		msg += " (not unlocked yet?)"
+	case http.StatusBadRequest, http.StatusInternalServerError:
+		msg += " (session token expired?)"
+	}
+	return msg
+}
+
+// Input downloads the puzzle input.
+func (c *Client) Input(ctx context.Context, year, day int) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d/input", year, day), nil)
+}
+
+// FetchInput writes the puzzle input to filename unless the file already
+// has content, and reports whether it was downloaded.
+func (c *Client) FetchInput(ctx context.Context, year, day int, filename string) (fetched bool, err error) {
+	if info, err := os.Stat(filename); err == nil && info.Size() > 0 {
+		return false, nil
+	}
+
+	data, err := c.Input(ctx, year, day)
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader) ([]byte, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimRight(c.BaseURL, "/") + path
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", c.UserAgent)
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	if body != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &StatusError{method, url, resp.StatusCode, string(data)}
+	}
+	return data, nil
+}
+
+// wait blocks until MinInterval has passed since the previous request.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.lastRequest.IsZero() {
+		delay := time.Until(c.lastRequest.Add(c.MinInterval))
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+			defer timer.Stop()
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-timer.C:
+			}
+		}
+	}
+	c.lastRequest = time.Now()
+	return nil
+}
@@ -0,0 +1,107 @@
+package aocweb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client of a local server answering with handler.
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+	client.HTTPClient = server.Client()
+	client.MinInterval = 0
+	return client
+}
+
+func TestFetchInput(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/2024/day/9/input" {
+			t.Errorf("path = %q", r.URL.Path)
+		}
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			t.Errorf("session cookie = %v, %v", cookie, err)
+		}
+		if ua := r.UserAgent(); ua != DefaultUserAgent {
+			t.Errorf("User-Agent = %q", ua)
+		}
+		w.Write([]byte("2333133121414131402\n"))
+	})
+
+	filename := filepath.Join(t.TempDir(), "2024", "09", "day09.input")
+	for i, wantFetched := range []bool{true, false} {
+		fetched, err := client.FetchInput(context.Background(), 2024, 9, filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fetched != wantFetched {
+			t.Errorf("call %d: fetched = %v, want %v", i+1, fetched, wantFetched)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "2333133121414131402\n" {
+		t.Errorf("input = %q", data)
+	}
+}
+
+func TestFetchInputEmptyFile(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("1\n"))
+	})
+
+	// aoc new creates an empty input file, which must not count as cached
+	filename := filepath.Join(t.TempDir(), "day01.input")
+	if err := os.WriteFile(filename, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	fetched, err := client.FetchInput(context.Background(), 2024, 1, filename)
+	if err != nil || !fetched {
+		t.Errorf("FetchInput = %v, %v, want true, nil", fetched, err)
+	}
+}
+
+func TestInputNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Please don't repeatedly request this endpoint before it unlocks!", http.StatusNotFound)
+	})
+
+	_, err := client.Input(context.Background(), 2024, 25)
+	var statusErr *StatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusNotFound {
+		t.Fatalf("err = %v, want a 404 StatusError", err)
+	}
+}
+
+func TestMinInterval(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {})
+	client.MinInterval = 50 * time.Millisecond
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Input(context.Background(), 2024, 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 2*client.MinInterval {
+		t.Errorf("3 requests took %v, want at least %v", elapsed, 2*client.MinInterval)
+	}
+}
@@ -0,0 +1,114 @@
+package aocweb
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Description downloads the puzzle page. Part two is only included once
+// part one is solved.
+func (c *Client) Description(ctx context.Context, year, day int) ([]byte, error) {
+	return c.do(ctx, http.MethodGet, fmt.Sprintf("/%d/day/%d", year, day), nil)
+}
+
+// Example is an example input found in a puzzle page.
+type Example struct {
+	Input string
+	// Answers maps a part to the answer the page gives for this example.
+	Answers map[int]int
+}
+
+var (
+	descPattern   = regexp.MustCompile(`(?s)<article class="day-desc">(.*?)</article>`)
+	prePattern    = regexp.MustCompile(`(?s)<pre><code>(.*?)</code></pre>`)
+	answerPattern = regexp.MustCompile(`<code><em>(-?\d+)</em></code>`)
+)
+
+// ExtractExamples finds the example inputs of a puzzle page: the code
+// blocks introduced as an example (see introducesExample). Other code blocks,
+// such as intermediate states, are skipped, and an example repeated in part
+// two is returned once.
+//
+// The last emphasized number after an example, up to the next example or
+// the end of the part, is taken as its answer for that part. A part without
+// its own example gives the answer for the latest one.
+func ExtractExamples(page string) (examples []Example) {
+	for i, desc := range descPattern.FindAllStringSubmatch(page, -1) {
+		part := i + 1
+		article := desc[1]
+
+		current := len(examples) - 1
+		// answers in article[spanStart:] belong to the current example
+		spanStart := 0
+		assign := func(text string) {
+			matches := answerPattern.FindAllStringSubmatch(text, -1)
+			if current < 0 || len(matches) == 0 {
+				return
+			}
+			answer, err := strconv.Atoi(matches[len(matches)-1][1])
+			if err != nil {
+				return
+			}
+			examples[current].Answers[part] = answer
+		}
+
+		prevEnd := 0
+		for _, loc := range prePattern.FindAllStringSubmatchIndex(article, -1) {
+			intro := article[prevEnd:loc[0]]
+			prevEnd = loc[1]
+			if !introducesExample(intro) {
+				continue
+			}
+			assign(article[spanStart:loc[0]])
+
+			input := html.UnescapeString(tagPattern.ReplaceAllString(article[loc[2]:loc[3]], ""))
+			current = indexOfExample(examples, input)
+			if current < 0 {
+				examples = append(examples, Example{input, make(map[int]int)})
+				current = len(examples) - 1
+			}
+			spanStart = loc[1]
+		}
+		assign(article[spanStart:])
+	}
+	return examples
+}
+
+// introducesExample reports whether the paragraph right before a code
+// block presents it as an example, e.g. "For example:" or "Consider the
+// following example list", but not "In the above example, ...".
+func introducesExample(intro string) bool {
+	if i := strings.LastIndex(intro, "<p>"); i >= 0 {
+		intro = intro[i:]
+	}
+	intro = strings.ToLower(intro)
+	if !strings.Contains(intro, "example") {
+		return false
+	}
+	return strings.Contains(intro, "for example") ||
+		strings.Contains(intro, "following") ||
+		strings.Contains(intro, "example input")
+}
+
+func indexOfExample(examples []Example, input string) int {
+	for i, example := range examples {
+		if example.Input == input {
+			return i
+		}
+	}
+	return -1
+}
+
+// ExampleName returns the input name of the i-th example (0-based):
+// "example", "example2", ...
+func ExampleName(i int) string {
+	if i == 0 {
+		return "example"
+	}
+	return "example" + strconv.Itoa(i+1)
+}
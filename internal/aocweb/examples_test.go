@@ -0,0 +1,42 @@
+package aocweb
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestExtractExamples(t *testing.T) {
+	tests := []struct {
+		fixture string
+		want    []Example
+	}{
+		{
+			// the intermediate state in part one is not an example, and
+			// part two brings its own example
+			"testdata/new_example.html",
+			[]Example{
+				{"1 2\n3 4\n", map[int]int{1: 10}},
+				{"5 <6\n7 8\n", map[int]int{2: 32}},
+			},
+		},
+		{
+			// part two reuses the example of part one
+			"testdata/same_example.html",
+			[]Example{
+				{"#.#\n.#.\n", map[int]int{1: 3, 2: 2}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		page, err := os.ReadFile(tt.fixture)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := ExtractExamples(string(page))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ExtractExamples(%s) = %q, want %q", tt.fixture, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package aocweb
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Attempt is a submitted answer. Attempts are logged next to the inputs in
+// dayNN.submissions, one "<time> <part> <answer> <verdict>" per line.
+type Attempt struct {
+	Time    time.Time
+	Part    int
+	Answer  int
+	Verdict Verdict
+}
+
+func (a Attempt) String() string {
+	return fmt.Sprintf("%s %d %d %s", a.Time.UTC().Format(time.RFC3339), a.Part, a.Answer, a.Verdict)
+}
+
+// ReadHistory reads a submissions log. A missing file means no attempts.
+func ReadHistory(filename string) (history []Attempt, err error) {
+	file, err := os.Open(filename)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("%s:%d: expected \"<time> <part> <answer> <verdict>\"", filename, lineNum)
+		}
+
+		var attempt Attempt
+		attempt.Time, err = time.Parse(time.RFC3339, fields[0])
+		if err == nil {
+			attempt.Part, err = strconv.Atoi(fields[1])
+		}
+		if err == nil {
+			attempt.Answer, err = strconv.Atoi(fields[2])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", filename, lineNum, err)
+		}
+		attempt.Verdict = Verdict(fields[3])
+		history = append(history, attempt)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// AppendHistory adds an attempt to the end of the log.
+func AppendHistory(filename string, attempt Attempt) error {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(file, attempt); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// CheckAttempt returns an error if the history already shows that answer is
+// wrong for the part: it was rejected before, or it is not below an answer
+// that was too high, or not above one that was too low.
+func CheckAttempt(history []Attempt, part, answer int) error {
+	for _, prev := range history {
+		if prev.Part != part {
+			continue
+		}
+		switch {
+		case prev.Verdict == Correct:
+			return fmt.Errorf("part %d was already solved with %d", part, prev.Answer)
+		case prev.Verdict.Wrong() && prev.Answer == answer:
+			return fmt.Errorf("%d was already submitted on %s: %s", answer, prev.Time.Format(time.DateTime), prev.Verdict)
+		case prev.Verdict == TooHigh && answer > prev.Answer:
+			return fmt.Errorf("%d is higher than %d, which was too high", answer, prev.Answer)
+		case prev.Verdict == TooLow && answer < prev.Answer:
+			return fmt.Errorf("%d is lower than %d, which was too low", answer, prev.Answer)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package aocweb
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Verdict is the website's judgement of a submitted answer.
+type Verdict string
+
+const (
+	Correct       Verdict = "correct"
+	Incorrect     Verdict = "incorrect"
+	TooHigh       Verdict = "too-high"
+	TooLow        Verdict = "too-low"
+	Wait          Verdict = "wait" // submitted too recently, not judged
+	AlreadySolved Verdict = "already-solved"
+	Unknown       Verdict = "unknown"
+)
+
+// Wrong reports whether the answer was judged and rejected.
+func (v Verdict) Wrong() bool {
+	return v == Incorrect || v == TooHigh || v == TooLow
+}
+
+type SubmitResult struct {
+	Verdict Verdict
+	// Wait is how long to wait before the next submission, if stated.
+	Wait time.Duration
+	// Message is the text of the response's article.
+	Message string
+}
+
+// Submit posts the answer of the part and parses the response.
+func (c *Client) Submit(ctx context.Context, year, day, part int, answer string) (SubmitResult, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	path := fmt.Sprintf("/%d/day/%d/answer", year, day)
+	body, err := c.do(ctx, http.MethodPost, path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return SubmitResult{}, err
+	}
+	return ParseSubmitResponse(string(body)), nil
+}
+
+var (
+	articlePattern = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagPattern     = regexp.MustCompile(`<[^>]*>`)
+	leftPattern    = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	minutesPattern = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+// ParseSubmitResponse classifies the HTML page returned for a submission.
+func ParseSubmitResponse(page string) SubmitResult {
+	message := page
+	if m := articlePattern.FindStringSubmatch(page); m != nil {
+		message = m[1]
+	}
+	message = html.UnescapeString(tagPattern.ReplaceAllString(message, ""))
+	message = strings.Join(strings.Fields(message), " ")
+
+	result := SubmitResult{Verdict: Unknown, Message: message}
+	switch {
+	case strings.Contains(message, "That's the right answer"):
+		result.Verdict = Correct
+	case strings.Contains(message, "You gave an answer too recently"):
+		result.Verdict = Wait
+	case strings.Contains(message, "solving the right level"):
+		result.Verdict = AlreadySolved
+	case strings.Contains(message, "That's not the right answer"):
+		result.Verdict = Incorrect
+		if strings.Contains(message, "your answer is too high") {
+			result.Verdict = TooHigh
+		} else if strings.Contains(message, "your answer is too low") {
+			result.Verdict = TooLow
+		}
+	}
+
+	if m := leftPattern.FindStringSubmatch(message); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		result.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if m := minutesPattern.FindStringSubmatch(message); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		result.Wait = time.Duration(minutes) * time.Minute
+	}
+	return result
+}
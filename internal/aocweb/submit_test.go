@@ -0,0 +1,123 @@
+package aocweb
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseSubmitResponse(t *testing.T) {
+	tests := []struct {
+		page        string
+		wantVerdict Verdict
+		wantWait    time.Duration
+	}{
+		{
+			`<main><article><p>That's the right answer!  You are <em>one gold star</em> closer. <a href="/2024/day/7#part2">[Continue to Part Two]</a></p></article></main>`,
+			Correct, 0,
+		},
+		{
+			`<article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2024/day/7">[Return to Day 7]</a></p></article>`,
+			TooHigh, time.Minute,
+		},
+		{
+			`<article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article>`,
+			TooLow, 5 * time.Minute,
+		},
+		{
+			`<article><p>That's not the right answer.  If you're stuck, make sure you're using the full input data.</p></article>`,
+			Incorrect, 0,
+		},
+		{
+			`<article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 1m 39s left to wait. <a href="/2024/day/7">[Return to Day 7]</a></p></article>`,
+			Wait, time.Minute + 39*time.Second,
+		},
+		{
+			`<article><p>You don't seem to be solving the right level.  Did you already complete it? <a href="/2024/day/7">[Return to Day 7]</a></p></article>`,
+			AlreadySolved, 0,
+		},
+		{`<html>maintenance</html>`, Unknown, 0},
+	}
+
+	for _, tt := range tests {
+		got := ParseSubmitResponse(tt.page)
+		if got.Verdict != tt.wantVerdict || got.Wait != tt.wantWait {
+			t.Errorf("ParseSubmitResponse(%.40q...) = %v, %v, want %v, %v", tt.page, got.Verdict, got.Wait, tt.wantVerdict, tt.wantWait)
+		}
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/7/answer" {
+			t.Errorf("request = %s %s", r.Method, r.URL.Path)
+		}
+		if level, answer := r.FormValue("level"), r.FormValue("answer"); level != "2" || answer != "11387" {
+			t.Errorf("form = level %q, answer %q", level, answer)
+		}
+		w.Write([]byte(`<article><p>That's the right answer!</p></article>`))
+	})
+
+	result, err := client.Submit(context.Background(), 2024, 7, 2, "11387")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Verdict != Correct {
+		t.Errorf("verdict = %v, want %v", result.Verdict, Correct)
+	}
+}
+
+func TestSubmitStatusError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "", http.StatusInternalServerError)
+	})
+
+	_, err := client.Submit(context.Background(), 2024, 7, 2, "11387")
+	if err == nil || !strings.HasPrefix(err.Error(), "POST ") {
+		t.Errorf("err = %v, want a failed POST", err)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "day07.submissions")
+	now := time.Date(2024, 12, 7, 6, 0, 0, 0, time.UTC)
+	for _, attempt := range []Attempt{
+		{now, 1, 100, TooLow},
+		{now, 1, 500, TooHigh},
+		{now, 1, 300, Incorrect},
+		{now, 2, 42, Correct},
+	} {
+		if err := AppendHistory(filename, attempt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := ReadHistory(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 4 || history[1] != (Attempt{now, 1, 500, TooHigh}) {
+		t.Fatalf("history = %v", history)
+	}
+
+	tests := []struct {
+		part, answer int
+		wantErr      bool
+	}{
+		{1, 200, false},
+		{1, 300, true}, // rejected before
+		{1, 100, true}, // too low before
+		{1, 50, true},
+		{1, 600, true}, // above a too high answer
+		{2, 43, true},  // already solved
+	}
+	for _, tt := range tests {
+		err := CheckAttempt(history, tt.part, tt.answer)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckAttempt(part %d, %d) = %v, want error %v", tt.part, tt.answer, err, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+// Package geom provides positions, vectors and directions on a 2D grid.
+package geom
+
+import "fmt"
+
+// Point is a position on a grid, rows growing downwards.
+// It is also used as a vector between two positions.
+type Point struct {
+	Row int `json:"row"`
+	Col int `json:"col"`
+}
+
+func (p Point) Add(q Point) Point {
+	return Point{p.Row + q.Row, p.Col + q.Col}
+}
+
+func (p Point) Sub(q Point) Point {
+	return Point{p.Row - q.Row, p.Col - q.Col}
+}
+
+func (p Point) Scale(k int) Point {
+	return Point{p.Row * k, p.Col * k}
+}
+
+// Move returns the neighbor of p in direction d.
+func (p Point) Move(d Direction) Point {
+	return p.Add(d.Delta())
+}
+
+// Manhattan returns the taxicab distance between p and q.
+func (p Point) Manhattan(q Point) int {
+	return abs(p.Row-q.Row) + abs(p.Col-q.Col)
+}
+
+// Chebyshev returns the king-move distance between p and q.
+func (p Point) Chebyshev(q Point) int {
+	return max(abs(p.Row-q.Row), abs(p.Col-q.Col))
+}
+
+// Step divides the vector p by the gcd of its components, giving the
+// smallest step that still visits every grid point on the line through p,
+// e.g. (4, -6) becomes (2, -3). The zero vector is returned unchanged.
+func (p Point) Step() Point {
+	g := gcd(abs(p.Row), abs(p.Col))
+	if g == 0 {
+		return p
+	}
+	return Point{p.Row / g, p.Col / g}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// Direction is one of the 8 compass directions, numbered clockwise from Up.
+type Direction int
+
+const (
+	Up Direction = iota
+	UpRight
+	Right
+	DownRight
+	Down
+	DownLeft
+	Left
+	UpLeft
+)
+
+var (
+	// Directions4 are the orthogonal directions clockwise from Up.
+	Directions4 = []Direction{Up, Right, Down, Left}
+	// Directions8 are all directions clockwise from Up.
+	Directions8 = []Direction{Up, UpRight, Right, DownRight, Down, DownLeft, Left, UpLeft}
+)
+
+var deltas = [...]Point{
+	Up:        {-1, 0},
+	UpRight:   {-1, 1},
+	Right:     {0, 1},
+	DownRight: {1, 1},
+	Down:      {1, 0},
+	DownLeft:  {1, -1},
+	Left:      {0, -1},
+	UpLeft:    {-1, -1},
+}
+
+var names = [...]string{"Up", "UpRight", "Right", "DownRight", "Down", "DownLeft", "Left", "UpLeft"}
+
+// Delta returns the vector of a single step in direction d.
+func (d Direction) Delta() Point {
+	return deltas[d]
+}
+
+// TurnRight turns 90 degrees clockwise.
+func (d Direction) TurnRight() Direction {
+	return (d + 2) % 8
+}
+
+// TurnLeft turns 90 degrees counterclockwise.
+func (d Direction) TurnLeft() Direction {
+	return (d + 6) % 8
+}
+
+func (d Direction) Reverse() Direction {
+	return (d + 4) % 8
+}
+
+func (d Direction) String() string {
+	if d < 0 || int(d) >= len(names) {
+		return fmt.Sprintf("Direction(%d)", int(d))
+	}
+	return names[d]
+}
+
+// MarshalText makes directions readable in JSON.
+func (d Direction) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+// ParseDirection parses an orthogonal direction written as an arrow
+// (^>v<), a compass letter (NESW) or a letter of UDLR.
+func ParseDirection(r rune) (Direction, error) {
+	switch r {
+	case '^', 'N', 'U':
+		return Up, nil
+	case '>', 'E', 'R':
+		return Right, nil
+	case 'v', 'S', 'D':
+		return Down, nil
+	case '<', 'W', 'L':
+		return Left, nil
+	default:
+		return 0, fmt.Errorf("invalid direction %q", r)
+	}
+}
@@ -0,0 +1,79 @@
+package geom
+
+import "testing"
+
+func TestStep(t *testing.T) {
+	tests := []struct {
+		p, want Point
+	}{
+		{Point{4, -6}, Point{2, -3}},
+		{Point{0, 5}, Point{0, 1}},
+		{Point{-3, 0}, Point{-1, 0}},
+		{Point{-4, -4}, Point{-1, -1}},
+		{Point{3, 5}, Point{3, 5}},
+		{Point{0, 0}, Point{0, 0}},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Step(); got != tt.want {
+			t.Errorf("%v.Step() = %v, want %v", tt.p, got, tt.want)
+		}
+	}
+}
+
+func TestDistances(t *testing.T) {
+	tests := []struct {
+		p, q                 Point
+		manhattan, chebyshev int
+	}{
+		{Point{0, 0}, Point{0, 0}, 0, 0},
+		{Point{1, 2}, Point{4, -2}, 7, 4},
+		{Point{-3, 5}, Point{-3, 1}, 4, 4},
+	}
+	for _, tt := range tests {
+		if got := tt.p.Manhattan(tt.q); got != tt.manhattan {
+			t.Errorf("%v.Manhattan(%v) = %d, want %d", tt.p, tt.q, got, tt.manhattan)
+		}
+		if got := tt.q.Chebyshev(tt.p); got != tt.chebyshev {
+			t.Errorf("%v.Chebyshev(%v) = %d, want %d", tt.q, tt.p, got, tt.chebyshev)
+		}
+	}
+}
+
+func TestTurns(t *testing.T) {
+	// the deltas rotate with the directions, diagonals included
+	for _, d := range Directions8 {
+		r, c := d.Delta().Row, d.Delta().Col
+		if got, want := d.TurnRight().Delta(), (Point{c, -r}); got != want {
+			t.Errorf("%v.TurnRight() = %v, want delta %v", d, d.TurnRight(), want)
+		}
+		if got, want := d.TurnLeft().Delta(), (Point{-c, r}); got != want {
+			t.Errorf("%v.TurnLeft() = %v, want delta %v", d, d.TurnLeft(), want)
+		}
+		if got, want := d.Reverse().Delta(), (Point{-r, -c}); got != want {
+			t.Errorf("%v.Reverse() = %v, want delta %v", d, d.Reverse(), want)
+		}
+	}
+
+	if got := UpLeft.TurnRight(); got != UpRight {
+		t.Errorf("UpLeft.TurnRight() = %v, want UpRight", got)
+	}
+	if got := UpRight.TurnLeft(); got != UpLeft {
+		t.Errorf("UpRight.TurnLeft() = %v, want UpLeft", got)
+	}
+}
+
+func TestParseDirection(t *testing.T) {
+	for want, s := range map[Direction]string{Up: "^NU", Right: ">ER", Down: "vSD", Left: "<WL"} {
+		for _, r := range s {
+			if got, err := ParseDirection(r); err != nil || got != want {
+				t.Errorf("ParseDirection(%q) = %v, %v, want %v", r, got, err, want)
+			}
+		}
+	}
+
+	for _, r := range "xV.u↑ " {
+		if d, err := ParseDirection(r); err == nil {
+			t.Errorf("ParseDirection(%q) = %v, want an error", r, d)
+		}
+	}
+}
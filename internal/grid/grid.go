@@ -0,0 +1,257 @@
+// Package grid provides a rectangular 2D grid of cells.
+package grid
+
+import (
+	"fmt"
+	"iter"
+
+	"github.com/thonda28/adventofcode/internal/geom"
+)
+
+// Grid is a rectangular grid stored in row-major order.
+// Positions are geom.Point with Row growing downwards.
+type Grid[T comparable] struct {
+	numRows, numCols int
+	cells            []T
+}
+
+// New returns a grid of the given size filled with the zero value.
+func New[T comparable](numRows, numCols int) *Grid[T] {
+	return &Grid[T]{numRows, numCols, make([]T, numRows*numCols)}
+}
+
+// FromRows copies rows into a new grid. All rows must have the same length.
+func FromRows[T comparable](rows [][]T) (*Grid[T], error) {
+	if len(rows) == 0 {
+		return New[T](0, 0), nil
+	}
+
+	g := New[T](len(rows), len(rows[0]))
+	for row, cells := range rows {
+		if len(cells) != g.numCols {
+			return nil, fmt.Errorf("row %d has %d cells, expected %d", row+1, len(cells), g.numCols)
+		}
+		copy(g.cells[row*g.numCols:], cells)
+	}
+	return g, nil
+}
+
+// FromCells returns a grid of the cells in row-major order, without
+// copying them. len(cells) must be numRows*numCols.
+func FromCells[T comparable](numRows, numCols int, cells []T) (*Grid[T], error) {
+	if numRows < 0 || numCols < 0 || len(cells) != numRows*numCols {
+		return nil, fmt.Errorf("%d cells for %dx%d", len(cells), numRows, numCols)
+	}
+	return &Grid[T]{numRows, numCols, cells}, nil
+}
+
+// FromLines returns a byte grid of the lines. All lines must have the same
+// length.
+func FromLines(lines []string) (*Grid[byte], error) {
+	rows := make([][]byte, len(lines))
+	for i, line := range lines {
+		rows[i] = []byte(line)
+	}
+	return FromRows(rows)
+}
+
+func (g *Grid[T]) NumRows() int { return g.numRows }
+func (g *Grid[T]) NumCols() int { return g.numCols }
+
+func (g *Grid[T]) InBounds(p geom.Point) bool {
+	return 0 <= p.Row && p.Row < g.numRows && 0 <= p.Col && p.Col < g.numCols
+}
+
+// Get returns the cell at p, which must be in bounds.
+func (g *Grid[T]) Get(p geom.Point) T {
+	return g.cells[g.index(p)]
+}
+
+// Set replaces the cell at p, which must be in bounds.
+func (g *Grid[T]) Set(p geom.Point, v T) {
+	g.cells[g.index(p)] = v
+}
+
+// Lookup returns the cell at p and whether p is in bounds.
+func (g *Grid[T]) Lookup(p geom.Point) (v T, ok bool) {
+	if !g.InBounds(p) {
+		return v, false
+	}
+	return g.Get(p), true
+}
+
+func (g *Grid[T]) index(p geom.Point) int {
+	if !g.InBounds(p) {
+		panic(fmt.Sprintf("grid: %v out of bounds %dx%d", p, g.numRows, g.numCols))
+	}
+	return p.Row*g.numCols + p.Col
+}
+
+// Row returns a copy of the cells of the row.
+func (g *Grid[T]) Row(row int) []T {
+	return append([]T(nil), g.cells[row*g.numCols:(row+1)*g.numCols]...)
+}
+
+func (g *Grid[T]) Clone() *Grid[T] {
+	return &Grid[T]{g.numRows, g.numCols, append([]T(nil), g.cells...)}
+}
+
+// All iterates over every cell in row-major order.
+func (g *Grid[T]) All() iter.Seq2[geom.Point, T] {
+	return func(yield func(geom.Point, T) bool) {
+		for i, v := range g.cells {
+			if !yield(geom.Point{Row: i / g.numCols, Col: i % g.numCols}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Find returns the first position holding v in row-major order.
+func (g *Grid[T]) Find(v T) (geom.Point, bool) {
+	for p, cell := range g.All() {
+		if cell == v {
+			return p, true
+		}
+	}
+	return geom.Point{}, false
+}
+
+// FindAll returns every position holding v in row-major order.
+func (g *Grid[T]) FindAll(v T) []geom.Point {
+	return g.FindFunc(func(cell T) bool { return cell == v })
+}
+
+// FindFunc returns every position whose cell satisfies f in row-major order.
+func (g *Grid[T]) FindFunc(f func(T) bool) (positions []geom.Point) {
+	for p, cell := range g.All() {
+		if f(cell) {
+			positions = append(positions, p)
+		}
+	}
+	return positions
+}
+
+// Neighbors4 iterates over the in-bounds orthogonal neighbors of p,
+// clockwise from up.
+func (g *Grid[T]) Neighbors4(p geom.Point) iter.Seq2[geom.Point, T] {
+	return g.neighbors(p, geom.Directions4)
+}
+
+// Neighbors8 iterates over the in-bounds orthogonal and diagonal neighbors
+// of p, clockwise from up.
+func (g *Grid[T]) Neighbors8(p geom.Point) iter.Seq2[geom.Point, T] {
+	return g.neighbors(p, geom.Directions8)
+}
+
+func (g *Grid[T]) neighbors(p geom.Point, directions []geom.Direction) iter.Seq2[geom.Point, T] {
+	return func(yield func(geom.Point, T) bool) {
+		for _, d := range directions {
+			q := p.Move(d)
+			if !g.InBounds(q) {
+				continue
+			}
+			if !yield(q, g.Get(q)) {
+				return
+			}
+		}
+	}
+}
+
+// Line iterates from start by step until leaving the grid.
+// start itself is included if it is in bounds.
+func (g *Grid[T]) Line(start, step geom.Point) iter.Seq2[geom.Point, T] {
+	return func(yield func(geom.Point, T) bool) {
+		if step == (geom.Point{}) {
+			panic("grid: zero step")
+		}
+		for p := start; g.InBounds(p); p = p.Add(step) {
+			if !yield(p, g.Get(p)) {
+				return
+			}
+		}
+	}
+}
+
+// RowCells iterates over the cells of the row from left to right.
+func (g *Grid[T]) RowCells(row int) iter.Seq2[geom.Point, T] {
+	return g.Line(geom.Point{Row: row, Col: 0}, geom.Right.Delta())
+}
+
+// ColCells iterates over the cells of the column from top to bottom.
+func (g *Grid[T]) ColCells(col int) iter.Seq2[geom.Point, T] {
+	return g.Line(geom.Point{Row: 0, Col: col}, geom.Down.Delta())
+}
+
+// Diagonals iterates over every top-left to bottom-right diagonal,
+// each one starting at its top-left end.
+func (g *Grid[T]) Diagonals() iter.Seq[iter.Seq2[geom.Point, T]] {
+	return g.diagonals(0, 1)
+}
+
+// AntiDiagonals iterates over every top-right to bottom-left diagonal,
+// each one starting at its top-right end.
+func (g *Grid[T]) AntiDiagonals() iter.Seq[iter.Seq2[geom.Point, T]] {
+	return g.diagonals(g.numCols-1, -1)
+}
+
+func (g *Grid[T]) diagonals(firstCol, colStep int) iter.Seq[iter.Seq2[geom.Point, T]] {
+	return func(yield func(iter.Seq2[geom.Point, T]) bool) {
+		step := geom.Point{Row: 1, Col: colStep}
+		// starts along the first row, then down the side column
+		for i := 0; i < g.numCols; i++ {
+			if !yield(g.Line(geom.Point{Row: 0, Col: firstCol + i*colStep}, step)) {
+				return
+			}
+		}
+		for row := 1; row < g.numRows; row++ {
+			if !yield(g.Line(geom.Point{Row: row, Col: firstCol}, step)) {
+				return
+			}
+		}
+	}
+}
+
+// Transpose returns a new grid mirrored along the main diagonal.
+func (g *Grid[T]) Transpose() *Grid[T] {
+	return g.remap(g.numCols, g.numRows, func(p geom.Point) geom.Point {
+		return geom.Point{Row: p.Col, Col: p.Row}
+	})
+}
+
+// RotateRight returns a new grid rotated 90 degrees clockwise.
+func (g *Grid[T]) RotateRight() *Grid[T] {
+	return g.remap(g.numCols, g.numRows, func(p geom.Point) geom.Point {
+		return geom.Point{Row: p.Col, Col: g.numRows - 1 - p.Row}
+	})
+}
+
+// RotateLeft returns a new grid rotated 90 degrees counterclockwise.
+func (g *Grid[T]) RotateLeft() *Grid[T] {
+	return g.remap(g.numCols, g.numRows, func(p geom.Point) geom.Point {
+		return geom.Point{Row: g.numCols - 1 - p.Col, Col: p.Row}
+	})
+}
+
+// FlipHorizontal returns a new grid mirrored left to right.
+func (g *Grid[T]) FlipHorizontal() *Grid[T] {
+	return g.remap(g.numRows, g.numCols, func(p geom.Point) geom.Point {
+		return geom.Point{Row: p.Row, Col: g.numCols - 1 - p.Col}
+	})
+}
+
+// FlipVertical returns a new grid mirrored top to bottom.
+func (g *Grid[T]) FlipVertical() *Grid[T] {
+	return g.remap(g.numRows, g.numCols, func(p geom.Point) geom.Point {
+		return geom.Point{Row: g.numRows - 1 - p.Row, Col: p.Col}
+	})
+}
+
+// remap builds a new grid where the cell at p moves to to(p).
+func (g *Grid[T]) remap(numRows, numCols int, to func(geom.Point) geom.Point) *Grid[T] {
+	result := New[T](numRows, numCols)
+	for p, v := range g.All() {
+		result.Set(to(p), v)
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package grid
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/geom"
+)
+
+// newTestGrid returns the 2x3 grid
+//
+//	abc
+//	def
+func newTestGrid(t *testing.T) *Grid[byte] {
+	t.Helper()
+
+	g, err := FromLines([]string{"abc", "def"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+func rows(g *Grid[byte]) (rows []string) {
+	for row := range g.NumRows() {
+		rows = append(rows, string(g.Row(row)))
+	}
+	return rows
+}
+
+func cells(seq iter.Seq2[geom.Point, byte]) string {
+	var cells []byte
+	for _, v := range seq {
+		cells = append(cells, v)
+	}
+	return string(cells)
+}
+
+func TestTransformations(t *testing.T) {
+	tests := []struct {
+		name      string
+		transform func(*Grid[byte]) *Grid[byte]
+		want      []string
+	}{
+		{"Transpose", (*Grid[byte]).Transpose, []string{"ad", "be", "cf"}},
+		{"RotateRight", (*Grid[byte]).RotateRight, []string{"da", "eb", "fc"}},
+		{"RotateLeft", (*Grid[byte]).RotateLeft, []string{"cf", "be", "ad"}},
+		{"FlipHorizontal", (*Grid[byte]).FlipHorizontal, []string{"cba", "fed"}},
+		{"FlipVertical", (*Grid[byte]).FlipVertical, []string{"def", "abc"}},
+	}
+	for _, tt := range tests {
+		g := newTestGrid(t)
+		if got := rows(tt.transform(g)); !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+		if got := rows(g); !slices.Equal(got, []string{"abc", "def"}) {
+			t.Errorf("%s modified the grid into %q", tt.name, got)
+		}
+	}
+}
+
+func TestDiagonals(t *testing.T) {
+	tests := []struct {
+		name      string
+		diagonals func(*Grid[byte]) iter.Seq[iter.Seq2[geom.Point, byte]]
+		want      []string
+	}{
+		{"Diagonals", (*Grid[byte]).Diagonals, []string{"ae", "bf", "c", "d"}},
+		{"AntiDiagonals", (*Grid[byte]).AntiDiagonals, []string{"ce", "bd", "a", "f"}},
+	}
+	for _, tt := range tests {
+		var got []string
+		for diagonal := range tt.diagonals(newTestGrid(t)) {
+			got = append(got, cells(diagonal))
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := newTestGrid(t)
+	tests := []struct {
+		name string
+		got  iter.Seq2[geom.Point, byte]
+		want string
+	}{
+		{"Neighbors4(0,1)", g.Neighbors4(geom.Point{Row: 0, Col: 1}), "cea"},
+		{"Neighbors8(0,1)", g.Neighbors8(geom.Point{Row: 0, Col: 1}), "cfeda"},
+		{"Neighbors8(1,0)", g.Neighbors8(geom.Point{Row: 1, Col: 0}), "abe"},
+		{"Neighbors8(1,2)", g.Neighbors8(geom.Point{Row: 1, Col: 2}), "ceb"},
+	}
+	for _, tt := range tests {
+		if got := cells(tt.got); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFromRowsRagged(t *testing.T) {
+	if _, err := FromLines([]string{"abc", "de"}); err == nil {
+		t.Error("FromLines accepted rows of different lengths")
+	}
+}
+
+func TestFromCells(t *testing.T) {
+	g, err := FromCells(2, 3, []byte("abcdef"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := rows(g); !slices.Equal(got, []string{"abc", "def"}) {
+		t.Errorf("FromCells = %q", got)
+	}
+	if _, err := FromCells(2, 3, []byte("abcde")); err == nil {
+		t.Error("FromCells accepted 5 cells for 2x3")
+	}
+}
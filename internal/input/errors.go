@@ -0,0 +1,78 @@
+package input
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ParseError describes a malformed part of an input file.
+// Line and Column are 1-based; 0 means unknown, e.g. for an error about the
+// file as a whole.
+type ParseError struct {
+	File   string
+	Line   int
+	Column int
+	Text   string // the offending text
+	Err    error
+}
+
+func (e *ParseError) Error() string {
+	location := e.File
+	if e.Line > 0 {
+		location += fmt.Sprintf(":%d", e.Line)
+		if e.Column > 0 {
+			location += fmt.Sprintf(":%d", e.Column)
+		}
+	}
+
+	msg := e.Err.Error()
+	if location != "" {
+		msg = location + ": " + msg
+	}
+	if e.Text != "" {
+		msg += fmt.Sprintf(" (%q)", e.Text)
+	}
+	return msg
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// fieldError reports err about the text found at the 1-based column.
+// The file and line are filled in by Line.Wrap.
+func fieldError(column int, text string, err error) error {
+	return &ParseError{Column: column, Text: text, Err: err}
+}
+
+// FileError returns a ParseError about the file as a whole.
+func FileError(filename string, err error) error {
+	return &ParseError{File: filename, Err: err}
+}
+
+// Wrap returns err as a ParseError located on the line. If err is already a
+// ParseError, e.g. from SplitInts, its column and text are kept.
+func (l Line) Wrap(err error) error {
+	var parseErr *ParseError
+	if errors.As(err, &parseErr) {
+		located := *parseErr
+		located.File = l.File
+		located.Line = l.Num
+		if located.Text == "" {
+			located.Text = l.Text
+		}
+		return &located
+	}
+	return &ParseError{File: l.File, Line: l.Num, Text: l.Text, Err: err}
+}
+
+// Errorf returns a ParseError located on the line.
+func (l Line) Errorf(format string, args ...any) error {
+	return l.Wrap(fmt.Errorf(format, args...))
+}
+
+// ErrorAt returns a ParseError about the text at the 1-based column of the
+// line.
+func (l Line) ErrorAt(column int, text string, err error) error {
+	return &ParseError{File: l.File, Line: l.Num, Column: column, Text: text, Err: err}
+}
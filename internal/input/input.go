@@ -0,0 +1,310 @@
+// Package input reads and parses puzzle inputs. Inputs are read from an
+// io.Reader, see Open for files, gzip and the standard input.
+//
+// Errors about the content of a file are *ParseError values carrying the
+// file, line, column and offending text, so that a bad line can be found
+// directly.
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/thonda28/adventofcode/internal/grid"
+)
+
+// Line is a line of an input, remembering where it came from.
+type Line struct {
+	File string
+	Num  int // 1-based
+	Text string
+}
+
+// Scan returns the lines of r one at a time, without line terminators.
+// Lines of any length are supported and nothing is kept after a line is
+// yielded, so inputs larger than memory can be processed. The File of
+// each line is Name(r).
+func Scan(r io.Reader) iter.Seq2[Line, error] {
+	return func(yield func(Line, error) bool) {
+		name := Name(r)
+		stopped := false
+		err := scanBytes(r, func(num int, text []byte) bool {
+			stopped = !yield(Line{name, num, string(text)}, nil)
+			return !stopped
+		})
+		if err != nil && !stopped {
+			yield(Line{}, err)
+		}
+	}
+}
+
+// scanBytes calls yield with the number and text of each line of r, without
+// line terminators, until yield returns false. The text is only valid until
+// yield returns.
+func scanBytes(r io.Reader, yield func(num int, text []byte) bool) error {
+	reader := bufio.NewReaderSize(r, 64*1024)
+	var long []byte // a line longer than the buffer, read in pieces
+	for num := 1; ; num++ {
+		text, err := reader.ReadSlice('\n')
+		for err == bufio.ErrBufferFull {
+			long = append(long, text...)
+			text, err = reader.ReadSlice('\n')
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		if len(long) > 0 {
+			long = append(long, text...)
+			text, long = long, long[:0]
+		}
+		if len(text) == 0 && err == io.EOF {
+			return nil
+		}
+		text = bytes.TrimSuffix(bytes.TrimSuffix(text, []byte("\n")), []byte("\r"))
+		if !yield(num, text) || err == io.EOF {
+			return nil
+		}
+	}
+}
+
+// ReadLines returns the lines of r without line terminators.
+func ReadLines(r io.Reader) (lines []string, err error) {
+	for line, err := range Scan(r) {
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line.Text)
+	}
+	return lines, nil
+}
+
+// Lines is like ReadLines but keeps the location of each line.
+func Lines(r io.Reader) (lines []Line, err error) {
+	for line, err := range Scan(r) {
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// Sections splits r at blank lines, e.g. the rules and the updates of
+// 2024/05. Leading, trailing and repeated blank lines do not produce empty
+// sections.
+func Sections(r io.Reader) (sections [][]Line, err error) {
+	lines, err := Lines(r)
+	if err != nil {
+		return nil, err
+	}
+	return SplitSections(lines), nil
+}
+
+// SplitSections splits the lines at blank lines like Sections.
+func SplitSections(lines []Line) (sections [][]Line) {
+	var section []Line
+	for _, line := range lines {
+		if strings.TrimSpace(line.Text) == "" {
+			if len(section) > 0 {
+				sections = append(sections, section)
+				section = nil
+			}
+			continue
+		}
+		section = append(section, line)
+	}
+	if len(section) > 0 {
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// Parse parses every line of r with parse, without keeping the lines.
+// Errors are returned as a ParseError located on the offending line.
+func Parse[T any](r io.Reader, parse func(text string) (T, error)) (values []T, err error) {
+	for line, err := range Scan(r) {
+		if err != nil {
+			return nil, err
+		}
+		value, err := parse(line.Text)
+		if err != nil {
+			return nil, line.Wrap(err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// ParseLines parses every line with parse. Errors are returned as a
+// ParseError located on the offending line.
+func ParseLines[T any](lines []Line, parse func(text string) (T, error)) ([]T, error) {
+	values := make([]T, 0, len(lines))
+	for _, line := range lines {
+		value, err := parse(line.Text)
+		if err != nil {
+			return nil, line.Wrap(err)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+var intPattern = regexp.MustCompile(`-?\d+`)
+
+// Ints extracts every integer in s, ignoring anything in between.
+// A '-' directly before digits makes the integer negative. An integer out
+// of range is reported as a ParseError with its column.
+func Ints(s string) (ints []int, err error) {
+	for _, match := range intPattern.FindAllStringIndex(s, -1) {
+		text := s[match[0]:match[1]]
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			return nil, fieldError(match[0]+1, text, errors.Unwrap(err))
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+// SplitInts parses s as integers separated by sep, e.g. "47|53" or
+// "75,47,61". An empty sep splits at runs of whitespace. A bad integer is
+// reported as a ParseError with its column.
+func SplitInts(s, sep string) (ints []int, err error) {
+	for _, field := range splitFields(s, sep) {
+		text := strings.TrimSpace(field.text)
+		n, err := strconv.Atoi(text)
+		if err != nil {
+			column := field.column + strings.Index(field.text, text)
+			return nil, fieldError(column, text, errors.Unwrap(err))
+		}
+		ints = append(ints, n)
+	}
+	return ints, nil
+}
+
+type field struct {
+	column int // 1-based
+	text   string
+}
+
+// splitFields is strings.Split, or strings.Fields for an empty sep, keeping
+// the column of each field.
+func splitFields(s, sep string) (fields []field) {
+	if sep != "" {
+		start := 0
+		for _, text := range strings.Split(s, sep) {
+			fields = append(fields, field{start + 1, text})
+			start += len(text) + len(sep)
+		}
+		return fields
+	}
+
+	start := -1
+	for i, r := range s + " " {
+		isSpace := unicode.IsSpace(r)
+		if start < 0 && !isSpace {
+			start = i
+		} else if start >= 0 && isSpace {
+			fields = append(fields, field{start + 1, s[start:i]})
+			start = -1
+		}
+	}
+	return fields
+}
+
+// Fields parses s as whitespace-separated integers.
+func Fields(s string) ([]int, error) {
+	return SplitInts(s, "")
+}
+
+// Pair parses s as exactly two integers separated by sep, e.g. "47|53".
+func Pair(s, sep string) (pair [2]int, err error) {
+	ints, err := SplitInts(s, sep)
+	if err != nil {
+		return pair, err
+	}
+	if len(ints) != 2 {
+		return pair, fmt.Errorf("expected 2 integers separated by %q, got %d", sep, len(ints))
+	}
+	return [2]int{ints[0], ints[1]}, nil
+}
+
+// KeyValues parses a "k: v1 v2 ..." line.
+func KeyValues(s string) (key int, values []int, err error) {
+	k, v, found := strings.Cut(s, ":")
+	if !found {
+		return 0, nil, errors.New(`missing ":"`)
+	}
+	key, err = strconv.Atoi(strings.TrimSpace(k))
+	if err != nil {
+		text := strings.TrimSpace(k)
+		return 0, nil, fieldError(strings.Index(s, text)+1, text, errors.Unwrap(err))
+	}
+	values, err = Fields(v)
+	if err != nil {
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			parseErr.Column += len(k) + 1
+		}
+		return 0, nil, err
+	}
+	return key, values, nil
+}
+
+// Grid reads r as a byte grid. All lines must have the same length. The
+// lines are appended to the cells of the grid as they are read, into a
+// buffer of the size of the input if it is known.
+func Grid(r io.Reader) (*grid.Grid[byte], error) {
+	cells := make([]byte, 0, sizeHint(r))
+	numRows, numCols := 0, 0
+	var rowErr error
+	err := scanBytes(r, func(num int, text []byte) bool {
+		if numRows == 0 {
+			numCols = len(text)
+		} else if len(text) != numCols {
+			line := Line{Name(r), num, string(text)}
+			rowErr = line.Errorf("row has %d cells, expected %d", len(text), numCols)
+			return false
+		}
+		cells = append(cells, text...)
+		numRows++
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if rowErr != nil {
+		return nil, rowErr
+	}
+	return grid.FromCells(numRows, numCols, cells)
+}
+
+// ByteGrid reads r as rows of bytes.
+func ByteGrid(r io.Reader) (rows [][]byte, err error) {
+	for line, err := range Scan(r) {
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []byte(line.Text))
+	}
+	return rows, nil
+}
+
+// RuneGrid reads r as rows of runes.
+func RuneGrid(r io.Reader) (rows [][]rune, err error) {
+	for line, err := range Scan(r) {
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, []rune(line.Text))
+	}
+	return rows, nil
+}
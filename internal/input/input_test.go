@@ -0,0 +1,118 @@
+package input
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// checkParseError checks that a ParseError from parsing s points at its
+// offending text.
+func checkParseError(t *testing.T, s string, err error) {
+	t.Helper()
+
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Column == 0 {
+		return
+	}
+	start := parseErr.Column - 1
+	if start+len(parseErr.Text) > len(s) || s[start:start+len(parseErr.Text)] != parseErr.Text {
+		t.Errorf("%q: column %d does not point at %q", s, parseErr.Column, parseErr.Text)
+	}
+}
+
+func FuzzSplitInts(f *testing.F) {
+	f.Add("75,47,61,53,29", ",")
+	f.Add("47|53", "|")
+	f.Add("3   4", "")
+	f.Add(" 7 6 4 2 1 ", "")
+	f.Add("1,,2", ",")
+	f.Add("x=1, y=-99999999999999999999", ",")
+
+	f.Fuzz(func(t *testing.T, s, sep string) {
+		ints, err := SplitInts(s, sep)
+		checkParseError(t, s, err)
+		if err == nil && sep != "" && len(ints) != strings.Count(s, sep)+1 {
+			t.Errorf("SplitInts(%q, %q) = %d integers, want %d", s, sep, len(ints), strings.Count(s, sep)+1)
+		}
+
+		_, err = Pair(s, sep)
+		checkParseError(t, s, err)
+
+		_, err = Ints(s)
+		checkParseError(t, s, err)
+	})
+}
+
+func FuzzKeyValues(f *testing.F) {
+	f.Add("190: 10 19")
+	f.Add("3267: 81 40 27")
+	f.Add("7290:6 8  6 15")
+	f.Add(": 1")
+	f.Add("x: 1 y")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		_, _, err := KeyValues(s)
+		checkParseError(t, s, err)
+	})
+}
+
+func FuzzScan(f *testing.F) {
+	f.Add("3   4\n4   3\n")
+	f.Add("a\r\nb")
+	f.Add("\n\n47|53\n\n75,47\n")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		lines, err := Lines(strings.NewReader(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, line := range lines {
+			if line.Num != i+1 {
+				t.Errorf("line %d has number %d", i+1, line.Num)
+			}
+			if strings.Contains(line.Text, "\n") {
+				t.Errorf("line %d contains a line break: %q", line.Num, line.Text)
+			}
+		}
+
+		var total int
+		for _, section := range SplitSections(lines) {
+			if len(section) == 0 {
+				t.Error("empty section")
+			}
+			total += len(section)
+		}
+		if total > len(lines) {
+			t.Errorf("sections have %d lines, input has %d", total, len(lines))
+		}
+
+		_, _ = Grid(strings.NewReader(s))
+	})
+}
+
+func TestIntsOverflow(t *testing.T) {
+	s := "x=1, y=-99999999999999999999"
+	_, err := Ints(s)
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.Column != 8 {
+		t.Errorf("Ints(%q) = %v, want an error at column 8", s, err)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	long := strings.Repeat("#", 100_000) // longer than the read buffer
+	g, err := Grid(strings.NewReader(long + "\r\n" + long + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.NumRows() != 2 || g.NumCols() != len(long) {
+		t.Errorf("grid is %dx%d, want 2x%d", g.NumRows(), g.NumCols(), len(long))
+	}
+
+	_, err = Grid(Named("grid.txt", strings.NewReader("...\n...\n..\n")))
+	var parseErr *ParseError
+	if !errors.As(err, &parseErr) || parseErr.File != "grid.txt" || parseErr.Line != 3 {
+		t.Errorf("ragged grid: %v, want an error on grid.txt:3", err)
+	}
+}
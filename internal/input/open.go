@@ -0,0 +1,108 @@
+package input
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+)
+
+// Stdin is the file name Open reads the standard input for.
+const Stdin = "-"
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// ErrEmptyStdin is returned by Open for a standard input without any data,
+// which is never meant as an input.
+var ErrEmptyStdin = errors.New("empty standard input")
+
+// Open opens the input file for reading. Gzip-compressed data is
+// decompressed on the fly, whatever the file name, and Stdin reads the
+// standard input. The reader has the file name, see Name.
+func Open(filename string) (io.ReadCloser, error) {
+	file, name := os.Stdin, "<stdin>"
+	if filename != Stdin {
+		var err error
+		if file, err = os.Open(filename); err != nil {
+			return nil, err
+		}
+		name = filename
+	}
+
+	buffered := bufio.NewReader(file)
+	reader := &namedReader{Reader: buffered, name: name, file: file}
+	if info, err := file.Stat(); err == nil && info.Mode().IsRegular() {
+		reader.size = int(info.Size())
+	}
+	if _, err := buffered.Peek(1); err == io.EOF && filename == Stdin {
+		return nil, FileError(name, ErrEmptyStdin)
+	}
+	if magic, _ := buffered.Peek(len(gzipMagic)); bytes.Equal(magic, gzipMagic) {
+		gz, err := gzip.NewReader(buffered)
+		if err != nil {
+			reader.Close()
+			return nil, FileError(name, err)
+		}
+		reader.Reader, reader.size = gz, 0
+	}
+	return reader, nil
+}
+
+// ReadFile is like os.ReadFile but reads the file like Open.
+func ReadFile(filename string) ([]byte, error) {
+	r, err := Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// Named returns r with the name used in errors about its content.
+func Named(name string, r io.Reader) io.Reader {
+	return &namedReader{Reader: r, name: name}
+}
+
+// Name returns the name of r: the file name of an *os.File or the name
+// given by Open or Named. It is empty for other readers.
+func Name(r io.Reader) string {
+	if named, ok := r.(interface{ Name() string }); ok {
+		return named.Name()
+	}
+	return ""
+}
+
+type namedReader struct {
+	io.Reader
+	name string
+	file *os.File // closed by Close unless it is the standard input
+	size int      // of the file, 0 if unknown or compressed
+}
+
+// sizeHint returns the size of the data of r, if it is known, so that it
+// can be read into a single buffer.
+func sizeHint(r io.Reader) int {
+	if named, ok := r.(*namedReader); ok {
+		if named.size > 0 {
+			return named.size
+		}
+		r = named.Reader
+	}
+	if sized, ok := r.(interface{ Len() int }); ok {
+		return sized.Len()
+	}
+	return 0
+}
+
+func (r *namedReader) Name() string {
+	return r.name
+}
+
+func (r *namedReader) Close() error {
+	if r.file == nil || r.file == os.Stdin {
+		return nil
+	}
+	return r.file.Close()
+}
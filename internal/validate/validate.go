@@ -0,0 +1,151 @@
+// Package validate checks the invariants a solver relies on, such as a
+// rectangular grid or a single start marker, before the input is parsed.
+//
+// A solver declares its invariants by implementing Validator. Every
+// violation is reported, each as an *input.ParseError pointing at the
+// offending line.
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+// Invariant is a named property of an input. Check returns every place
+// where the lines violate it.
+type Invariant struct {
+	Name  string
+	Check func(lines []input.Line) []error
+}
+
+// Validator is implemented by solvers that declare the invariants of their
+// input.
+type Validator interface {
+	Invariants() []Invariant
+}
+
+// Check checks the lines against every invariant and joins the violations,
+// each annotated with the name of its invariant.
+func Check(lines []input.Line, invariants []Invariant) error {
+	var errs []error
+	for _, invariant := range invariants {
+		for _, err := range invariant.Check(lines) {
+			errs = append(errs, annotate(invariant.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// annotate puts the name after the location of err, e.g.
+// `day06.input:3:5: single "^>v<" marker: another marker ("^")`.
+func annotate(name string, err error) error {
+	var parseErr *input.ParseError
+	if errors.As(err, &parseErr) {
+		annotated := *parseErr
+		annotated.Err = fmt.Errorf("%s: %w", name, parseErr.Err)
+		return &annotated
+	}
+	return fmt.Errorf("%s: %w", name, err)
+}
+
+// Rectangular requires every line to be as long as the first one.
+func Rectangular() Invariant {
+	return Invariant{"rectangular grid", func(lines []input.Line) (errs []error) {
+		for _, line := range lines {
+			if len(line.Text) != len(lines[0].Text) {
+				errs = append(errs, line.Errorf("row has %d cells, expected %d", len(line.Text), len(lines[0].Text)))
+			}
+		}
+		return errs
+	}}
+}
+
+// SingleMarker requires exactly one of the marker bytes to appear, once.
+func SingleMarker(markers string) Invariant {
+	name := fmt.Sprintf("single %q marker", markers)
+	return Invariant{name, func(lines []input.Line) (errs []error) {
+		count := 0
+		for _, line := range lines {
+			for i := range len(line.Text) {
+				if strings.IndexByte(markers, line.Text[i]) < 0 {
+					continue
+				}
+				count++
+				if count > 1 {
+					errs = append(errs, line.ErrorAt(i+1, line.Text[i:i+1], errors.New("another marker")))
+				}
+			}
+		}
+		if count == 0 && len(lines) > 0 {
+			errs = append(errs, input.FileError(lines[0].File, errors.New("no marker")))
+		}
+		return errs
+	}}
+}
+
+// FieldCount requires every line to have n fields separated by sep, see
+// input.SplitInts.
+func FieldCount(name, sep string, n int) Invariant {
+	return Invariant{name, func(lines []input.Line) (errs []error) {
+		for _, line := range lines {
+			if count := len(split(line.Text, sep)); count != n {
+				errs = append(errs, line.Errorf("expected %d fields, got %d", n, count))
+			}
+		}
+		return errs
+	}}
+}
+
+// OddLength requires every line to have an odd number of fields separated
+// by sep, so that it has a middle one.
+func OddLength(sep string) Invariant {
+	return Invariant{"odd-length lists", func(lines []input.Line) (errs []error) {
+		for _, line := range lines {
+			if count := len(split(line.Text, sep)); count%2 == 0 {
+				errs = append(errs, line.Errorf("even number of elements: %d", count))
+			}
+		}
+		return errs
+	}}
+}
+
+// Unique requires the fields separated by sep to be unique on every line.
+func Unique(sep string) Invariant {
+	return Invariant{"unique elements", func(lines []input.Line) (errs []error) {
+		for _, line := range lines {
+			offset := 0
+			seen := make(map[string]bool)
+			for _, field := range split(line.Text, sep) {
+				column := offset + strings.Index(line.Text[offset:], field) + 1
+				offset = column - 1 + len(field)
+				if seen[field] {
+					errs = append(errs, line.ErrorAt(column, field, errors.New("duplicate element")))
+				}
+				seen[field] = true
+			}
+		}
+		return errs
+	}}
+}
+
+// InSection applies the invariant to the i-th (0-based) section of the
+// lines, see input.Sections. A missing section is left to the parser.
+func InSection(i int, invariant Invariant) Invariant {
+	return Invariant{invariant.Name, func(lines []input.Line) []error {
+		sections := input.SplitSections(lines)
+		if i >= len(sections) {
+			return nil
+		}
+		return invariant.Check(sections[i])
+	}}
+}
+
+func split(s, sep string) []string {
+	if sep == "" {
+		return strings.Fields(s)
+	}
+	return strings.Split(s, sep)
+}
@@ -0,0 +1,95 @@
+package validate
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/thonda28/adventofcode/internal/input"
+)
+
+func lines(t *testing.T, s string) []input.Line {
+	t.Helper()
+
+	lines, err := input.Lines(input.Named("test.txt", strings.NewReader(s)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lines
+}
+
+// messages returns the error messages of the violations, in order.
+func messages(errs []error) (msgs []string) {
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+	return msgs
+}
+
+func TestInvariants(t *testing.T) {
+	tests := []struct {
+		name      string
+		invariant Invariant
+		input     string
+		want      []string
+	}{
+		{
+			"Unique with separator", Unique(","), "75,47,75,47\n1,2\n4,44,4",
+			[]string{
+				`test.txt:1:7: duplicate element ("75")`,
+				`test.txt:1:10: duplicate element ("47")`,
+				`test.txt:3:6: duplicate element ("4")`,
+			},
+		},
+		{
+			"Unique with whitespace", Unique(""), " 12  1 12\n",
+			[]string{`test.txt:1:8: duplicate element ("12")`},
+		},
+		{
+			"SingleMarker", SingleMarker("^v"), "..^\nv.^\n",
+			[]string{
+				`test.txt:2:1: another marker ("v")`,
+				`test.txt:2:3: another marker ("^")`,
+			},
+		},
+		{
+			"SingleMarker without marker", SingleMarker("^"), "...\n...\n",
+			[]string{"test.txt: no marker"},
+		},
+		{
+			"InSection", InSection(1, OddLength(",")), "1,2\n\n1,2\n1,2,3\n",
+			[]string{`test.txt:3: even number of elements: 2 ("1,2")`},
+		},
+		{
+			"InSection with a missing section", InSection(2, OddLength(",")), "1,2\n\n1,2\n",
+			nil,
+		},
+		{
+			"Rectangular", Rectangular(), "...\n..\n...\n....\n",
+			[]string{
+				`test.txt:2: row has 2 cells, expected 3 ("..")`,
+				`test.txt:4: row has 4 cells, expected 3 ("....")`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		if got := messages(tt.invariant.Check(lines(t, tt.input))); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCheckJoinsEveryViolation(t *testing.T) {
+	err := Check(lines(t, "^.^\n..\n"), []Invariant{Rectangular(), SingleMarker("^")})
+	want := []string{
+		`test.txt:2: rectangular grid: row has 2 cells, expected 3 ("..")`,
+		`test.txt:1:3: single "^" marker: another marker ("^")`,
+	}
+	if err == nil || !slices.Equal(strings.Split(err.Error(), "\n"), want) {
+		t.Errorf("Check = %v, want %q", err, want)
+	}
+
+	if err := Check(lines(t, "^.\n..\n"), []Invariant{Rectangular(), SingleMarker("^")}); err != nil {
+		t.Errorf("Check = %v, want no violation", err)
+	}
+}